@@ -0,0 +1,198 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package appconfig reads and writes the App Services configuration directory,
+// the layout rooted at realm_config.json that is exported by the Admin API and
+// used for GitHub automatic deployment.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/reference/config/
+package appconfig // import "github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appconfig"
+
+import (
+	"encoding/json"
+
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appservices"
+)
+
+// File and directory names of the configuration layout.
+const (
+	RealmConfigFile    = "realm_config.json"
+	TriggersDir        = "triggers"
+	FunctionsDir       = "functions"
+	DataSourcesDir     = "data_sources"
+	AuthDir            = "auth"
+	EnvironmentsDir    = "environments"
+	ValuesDir          = "values"
+	HTTPEndpointsDir   = "http_endpoints"
+	ConfigFile         = "config.json"
+	ProvidersFile      = "providers.json"
+	CustomUserDataFile = "custom_user_data.json"
+	DefaultRuleFile    = "default_rule.json"
+	RulesFile          = "rules.json"
+	SchemaFile         = "schema.json"
+	RelationshipsFile  = "relationships.json"
+	functionSourceExt  = ".js"
+	jsonExt            = ".json"
+)
+
+// App is the in-memory representation of an App Services configuration directory.
+type App struct {
+	Config        AppConfig
+	Triggers      []Trigger
+	Functions     []Function
+	DataSources   []DataSource
+	Auth          Auth
+	Environments  map[string]Environment
+	Values        []Value
+	HTTPEndpoints []HTTPEndpoint
+}
+
+// AppConfig represents realm_config.json.
+type AppConfig struct {
+	AppID           string `json:"app_id,omitempty"`
+	ConfigVersion   int    `json:"config_version,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Location        string `json:"location,omitempty"`
+	DeploymentModel string `json:"deployment_model,omitempty"`
+	ProviderRegion  string `json:"provider_region,omitempty"`
+	Environment     string `json:"environment,omitempty"`
+}
+
+// Trigger represents a triggers/<name>.json file.
+type Trigger struct {
+	appservices.EventTrigger
+	// ServiceName is the name of the data source of a database trigger, stored
+	// as config.service_name in place of the service ID used by the Admin API.
+	ServiceName string `json:"-"`
+}
+
+// MarshalJSON encodes the trigger with its service name inside the config.
+func (t *Trigger) MarshalJSON() ([]byte, error) {
+	config := struct {
+		appservices.EventTriggerConfig
+		ServiceName string `json:"service_name,omitempty"`
+	}{EventTriggerConfig: t.Config, ServiceName: t.ServiceName}
+
+	return json.Marshal(struct {
+		*appservices.EventTrigger
+		Config interface{} `json:"config,omitempty"`
+	}{EventTrigger: &t.EventTrigger, Config: config})
+}
+
+// UnmarshalJSON decodes the trigger along with the service name of its config.
+func (t *Trigger) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Config struct {
+			ServiceName string `json:"service_name"`
+		} `json:"config"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	t.ServiceName = aux.Config.ServiceName
+	return json.Unmarshal(data, &t.EventTrigger)
+}
+
+// Function represents an entry of functions/config.json along with its source file.
+type Function struct {
+	CanEvaluate    interface{} `json:"can_evaluate,omitempty"`
+	Name           string      `json:"name"`
+	Private        bool        `json:"private"`
+	RunAsSystem    bool        `json:"run_as_system,omitempty"`
+	DisableArgLogs bool        `json:"disable_arg_logs,omitempty"`
+	// Source is read from and written to functions/<name>.js.
+	Source string `json:"-"`
+}
+
+// DataSource represents a data_sources/<name> directory.
+type DataSource struct {
+	Name    string                 `json:"name"`
+	Type    string                 `json:"type"`
+	Config  map[string]interface{} `json:"config,omitempty"`
+	Version int                    `json:"version,omitempty"`
+	// DefaultRule is read from and written to data_sources/<name>/default_rule.json.
+	DefaultRule json.RawMessage `json:"-"`
+	// Collections are read from and written to data_sources/<name>/<database>/<collection>.
+	Collections []Collection `json:"-"`
+}
+
+// Collection represents a data_sources/<name>/<database>/<collection> directory.
+type Collection struct {
+	Database      string
+	Collection    string
+	Rules         json.RawMessage
+	Schema        json.RawMessage
+	Relationships json.RawMessage
+}
+
+// Auth represents the auth directory.
+type Auth struct {
+	Providers      map[string]AuthProvider
+	CustomUserData *CustomUserData
+}
+
+// AuthProvider represents an entry of auth/providers.json.
+type AuthProvider struct {
+	Config             map[string]interface{} `json:"config,omitempty"`
+	SecretConfig       map[string]interface{} `json:"secret_config,omitempty"`
+	Name               string                 `json:"name"`
+	Type               string                 `json:"type"`
+	Disabled           bool                   `json:"disabled"`
+	MetadataFields     []MetadataField        `json:"metadata_fields,omitempty"`
+	RedirectURIs       []string               `json:"redirect_uris,omitempty"`
+	DomainRestrictions []string               `json:"domain_restrictions,omitempty"`
+}
+
+// MetadataField represents a user metadata field populated by an auth provider.
+type MetadataField struct {
+	Name      string `json:"name"`
+	Required  bool   `json:"required"`
+	FieldName string `json:"field_name,omitempty"`
+}
+
+// CustomUserData represents auth/custom_user_data.json.
+type CustomUserData struct {
+	Enabled                    bool   `json:"enabled"`
+	MongoServiceName           string `json:"mongo_service_name,omitempty"`
+	DatabaseName               string `json:"database_name,omitempty"`
+	CollectionName             string `json:"collection_name,omitempty"`
+	UserIDField                string `json:"user_id_field,omitempty"`
+	OnUserCreationFunctionName string `json:"on_user_creation_function_name,omitempty"`
+}
+
+// Environment represents an environments/<environment>.json file.
+type Environment struct {
+	Values map[string]interface{} `json:"values"`
+}
+
+// Value represents a values/<name>.json file.
+type Value struct {
+	Value      interface{} `json:"value"`
+	Name       string      `json:"name"`
+	FromSecret bool        `json:"from_secret"`
+}
+
+// HTTPEndpoint represents an entry of http_endpoints/config.json.
+type HTTPEndpoint struct {
+	Route               string `json:"route"`
+	HTTPMethod          string `json:"http_method"`
+	FunctionName        string `json:"function_name"`
+	ValidationMethod    string `json:"validation_method,omitempty"`
+	SecretName          string `json:"secret_name,omitempty"`
+	ReturnType          string `json:"return_type,omitempty"`
+	RespondResult       bool   `json:"respond_result"`
+	FetchCustomUserData bool   `json:"fetch_custom_user_data"`
+	CreateUser          bool   `json:"create_user_on_auth"`
+	Disabled            bool   `json:"disabled"`
+}
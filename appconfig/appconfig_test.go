@@ -0,0 +1,282 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appconfig
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appservices"
+)
+
+var fixture = map[string]string{
+	"realm_config.json": `{
+		"app_id": "myapp-abcde",
+		"config_version": 20210101,
+		"name": "myapp",
+		"location": "US-VA",
+		"deployment_model": "GLOBAL"
+	}`,
+	"triggers/onInsert.json": `{
+		"name": "onInsert",
+		"type": "DATABASE",
+		"function_name": "handleInsert",
+		"disabled": false,
+		"config": {
+			"service_name": "mongodb-atlas",
+			"operation_types": ["INSERT"],
+			"database": "db",
+			"collection": "coll",
+			"match": {"fullDocument.status": "new"}
+		}
+	}`,
+	"functions/config.json": `[
+		{"name": "handleInsert", "private": true},
+		{"name": "utils/format", "private": false, "run_as_system": true}
+	]`,
+	"functions/handleInsert.js": "exports = function(event) {};\n",
+	"functions/utils/format.js": "exports = function(v) { return v; };\n",
+	"data_sources/mongodb-atlas/config.json": `{
+		"name": "mongodb-atlas",
+		"type": "mongodb-atlas",
+		"config": {"clusterName": "Cluster0", "wireProtocolEnabled": false}
+	}`,
+	"data_sources/mongodb-atlas/default_rule.json":          `{"roles": []}`,
+	"data_sources/mongodb-atlas/db/coll/rules.json":         `{"database": "db", "collection": "coll"}`,
+	"data_sources/mongodb-atlas/db/coll/schema.json":        `{"bsonType": "object"}`,
+	"data_sources/mongodb-atlas/db/coll/relationships.json": `{}`,
+	"auth/providers.json": `{
+		"anon-user": {"name": "anon-user", "type": "anon-user", "disabled": false}
+	}`,
+	"auth/custom_user_data.json": `{
+		"enabled": true,
+		"mongo_service_name": "mongodb-atlas",
+		"database_name": "db",
+		"collection_name": "users",
+		"user_id_field": "user_id"
+	}`,
+	"environments/production.json": `{"values": {"tier": "prod"}}`,
+	"values/apiKey.json":           `{"name": "apiKey", "value": "secretApiKey", "from_secret": true}`,
+	"http_endpoints/config.json": `[
+		{"route": "/hook", "http_method": "POST", "function_name": "handleInsert", "validation_method": "NO_VALIDATION"}
+	]`,
+}
+
+func writeFixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range fixture {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func compact(t *testing.T, s string) json.RawMessage {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := json.Marshal(v)
+	return b
+}
+
+func TestRead(t *testing.T) {
+	app, err := Read(writeFixture(t))
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	expected := &App{
+		Config: AppConfig{
+			AppID:           "myapp-abcde",
+			ConfigVersion:   20210101,
+			Name:            "myapp",
+			Location:        "US-VA",
+			DeploymentModel: "GLOBAL",
+		},
+		Triggers: []Trigger{
+			{
+				EventTrigger: appservices.EventTrigger{
+					Name:         "onInsert",
+					Type:         "DATABASE",
+					FunctionName: "handleInsert",
+					Disabled:     pointer(false),
+					Config: appservices.EventTriggerConfig{
+						OperationTypes: []string{"INSERT"},
+						Database:       "db",
+						Collection:     "coll",
+						Match:          map[string]interface{}{"fullDocument.status": "new"},
+					},
+				},
+				ServiceName: "mongodb-atlas",
+			},
+		},
+		Functions: []Function{
+			{Name: "handleInsert", Private: true, Source: "exports = function(event) {};\n"},
+			{Name: "utils/format", RunAsSystem: true, Source: "exports = function(v) { return v; };\n"},
+		},
+		DataSources: []DataSource{
+			{
+				Name:        "mongodb-atlas",
+				Type:        "mongodb-atlas",
+				Config:      map[string]interface{}{"clusterName": "Cluster0", "wireProtocolEnabled": false},
+				DefaultRule: json.RawMessage(fixture["data_sources/mongodb-atlas/default_rule.json"]),
+				Collections: []Collection{
+					{
+						Database:      "db",
+						Collection:    "coll",
+						Rules:         json.RawMessage(fixture["data_sources/mongodb-atlas/db/coll/rules.json"]),
+						Schema:        json.RawMessage(fixture["data_sources/mongodb-atlas/db/coll/schema.json"]),
+						Relationships: json.RawMessage(fixture["data_sources/mongodb-atlas/db/coll/relationships.json"]),
+					},
+				},
+			},
+		},
+		Auth: Auth{
+			Providers: map[string]AuthProvider{
+				"anon-user": {Name: "anon-user", Type: "anon-user"},
+			},
+			CustomUserData: &CustomUserData{
+				Enabled:          true,
+				MongoServiceName: "mongodb-atlas",
+				DatabaseName:     "db",
+				CollectionName:   "users",
+				UserIDField:      "user_id",
+			},
+		},
+		Environments: map[string]Environment{
+			"production": {Values: map[string]interface{}{"tier": "prod"}},
+		},
+		Values: []Value{
+			{Name: "apiKey", Value: "secretApiKey", FromSecret: true},
+		},
+		HTTPEndpoints: []HTTPEndpoint{
+			{Route: "/hook", HTTPMethod: "POST", FunctionName: "handleInsert", ValidationMethod: "NO_VALIDATION"},
+		},
+	}
+
+	if diff := deep.Equal(app, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestRead_missingRealmConfig(t *testing.T) {
+	if _, err := Read(t.TempDir()); !os.IsNotExist(err) {
+		t.Errorf("Read returned %v, expected a not exist error", err)
+	}
+}
+
+func TestWrite_roundTrip(t *testing.T) {
+	app, err := Read(writeFixture(t))
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := Write(dir, app); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	var trigger struct {
+		Config map[string]interface{} `json:"config"`
+	}
+	b, err := os.ReadFile(filepath.Join(dir, TriggersDir, "onInsert.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &trigger); err != nil {
+		t.Fatal(err)
+	}
+	if name := trigger.Config["service_name"]; name != "mongodb-atlas" {
+		t.Errorf("config.service_name = %v, expected mongodb-atlas", name)
+	}
+
+	got, err := Read(dir)
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	// Raw documents are re-indented on write, so compare them semantically.
+	for i := range got.DataSources {
+		ds, orig := &got.DataSources[i], &app.DataSources[i]
+		if diff := deep.Equal(compact(t, string(ds.DefaultRule)), compact(t, string(orig.DefaultRule))); diff != nil {
+			t.Error(diff)
+		}
+		ds.DefaultRule, orig.DefaultRule = nil, nil
+		for j := range ds.Collections {
+			c, o := &ds.Collections[j], &orig.Collections[j]
+			if diff := deep.Equal(compact(t, string(c.Rules)), compact(t, string(o.Rules))); diff != nil {
+				t.Error(diff)
+			}
+			if diff := deep.Equal(compact(t, string(c.Schema)), compact(t, string(o.Schema))); diff != nil {
+				t.Error(diff)
+			}
+			c.Rules, c.Schema, c.Relationships = nil, nil, nil
+			o.Rules, o.Schema, o.Relationships = nil, nil, nil
+		}
+	}
+
+	if diff := deep.Equal(got, app); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestWrite_invalidNames(t *testing.T) {
+	for name, app := range map[string]*App{
+		"trigger":          {Triggers: []Trigger{{EventTrigger: appservices.EventTrigger{Name: "../x"}}}},
+		"function":         {Functions: []Function{{Name: "../../x"}}},
+		"unnamed function": {Functions: []Function{{Name: ""}}},
+		"data source":      {DataSources: []DataSource{{Name: "../x"}}},
+		"collection":       {DataSources: []DataSource{{Name: "atlas", Collections: []Collection{{Database: "db", Collection: "../../../x"}}}}},
+		"environment":      {Environments: map[string]Environment{"../x": {}}},
+		"value":            {Values: []Value{{Name: "a/../../x"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			parent := t.TempDir()
+			if err := Write(filepath.Join(parent, "app"), app); err == nil {
+				t.Error("expected an error for an invalid name")
+			}
+			if _, err := os.Stat(filepath.Join(parent, "x.json")); !os.IsNotExist(err) {
+				t.Errorf("expected nothing written outside of the app directory, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWrite_nestedFunction(t *testing.T) {
+	dir := t.TempDir()
+	app := &App{Functions: []Function{{Name: "utils/format", Source: "exports = () => {};"}}}
+	if err := Write(dir, app); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, FunctionsDir, "utils", "format.js")); err != nil {
+		t.Errorf("expected the function source to be nested: %v", err)
+	}
+}
+
+func pointer[T any](x T) *T {
+	return &x
+}
@@ -0,0 +1,494 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	dirPerm  = 0o755
+	filePerm = 0o644
+)
+
+// Read loads the configuration directory rooted at dir. Only realm_config.json
+// is required, every other file and directory is optional.
+func Read(dir string) (*App, error) {
+	app := &App{}
+
+	if err := readJSON(filepath.Join(dir, RealmConfigFile), &app.Config); err != nil {
+		return nil, err
+	}
+
+	steps := []func(string, *App) error{
+		readTriggers,
+		readFunctions,
+		readDataSources,
+		readAuth,
+		readEnvironments,
+		readValues,
+		readHTTPEndpoints,
+	}
+	for _, step := range steps {
+		if err := step(dir, app); err != nil {
+			return nil, err
+		}
+	}
+
+	return app, nil
+}
+
+// Write stores app in the configuration directory rooted at dir, creating it
+// if needed. Files that already exist in dir but are not part of app are left untouched.
+// Resource names that would place a file outside of dir are rejected.
+func Write(dir string, app *App) error {
+	if app == nil {
+		return errors.New("appconfig: app cannot be nil")
+	}
+
+	if err := writeJSON(filepath.Join(dir, RealmConfigFile), app.Config); err != nil {
+		return err
+	}
+
+	steps := []func(string, *App) error{
+		writeTriggers,
+		writeFunctions,
+		writeDataSources,
+		writeAuth,
+		writeEnvironments,
+		writeValues,
+		writeHTTPEndpoints,
+	}
+	for _, step := range steps {
+		if err := step(dir, app); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readTriggers(dir string, app *App) error {
+	return readEach(filepath.Join(dir, TriggersDir), func(path string) error {
+		var trigger Trigger
+		if err := readJSON(path, &trigger); err != nil {
+			return err
+		}
+		app.Triggers = append(app.Triggers, trigger)
+		return nil
+	})
+}
+
+func writeTriggers(dir string, app *App) error {
+	for i := range app.Triggers {
+		t := &app.Triggers[i]
+		if t.Name == "" {
+			return fmt.Errorf("appconfig: trigger %d has no name", i)
+		}
+		if err := checkFileName("trigger", t.Name); err != nil {
+			return err
+		}
+		if err := writeJSON(filepath.Join(dir, TriggersDir, t.Name+jsonExt), t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readFunctions(dir string, app *App) error {
+	root := filepath.Join(dir, FunctionsDir)
+	var functions []Function
+	if err := readOptionalJSON(filepath.Join(root, ConfigFile), &functions); err != nil {
+		return err
+	}
+
+	for i := range functions {
+		f := &functions[i]
+		path, err := functionSourcePath(root, i, f.Name)
+		if err != nil {
+			return err
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("appconfig: function %q: %w", f.Name, err)
+		}
+		f.Source = string(src)
+	}
+
+	app.Functions = functions
+	return nil
+}
+
+func writeFunctions(dir string, app *App) error {
+	if len(app.Functions) == 0 {
+		return nil
+	}
+
+	root := filepath.Join(dir, FunctionsDir)
+	for i := range app.Functions {
+		if _, err := functionSourcePath(root, i, app.Functions[i].Name); err != nil {
+			return err
+		}
+	}
+	if err := writeJSON(filepath.Join(root, ConfigFile), app.Functions); err != nil {
+		return err
+	}
+
+	for i := range app.Functions {
+		f := &app.Functions[i]
+		path, err := functionSourcePath(root, i, f.Name)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(path, []byte(f.Source)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// functionSourcePath returns the path of the source of the i-th function, whose
+// name may contain slashes to nest it in directories.
+func functionSourcePath(root string, i int, name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("appconfig: function %d has no name", i)
+	}
+	path := filepath.FromSlash(name)
+	if !filepath.IsLocal(path) {
+		return "", fmt.Errorf("appconfig: function %q: name is not a local path", name)
+	}
+	return filepath.Join(root, path+functionSourceExt), nil
+}
+
+func readDataSources(dir string, app *App) error {
+	root := filepath.Join(dir, DataSourcesDir)
+	entries, err := readDirs(root)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range entries {
+		dsDir := filepath.Join(root, name)
+
+		var ds DataSource
+		if err := readJSON(filepath.Join(dsDir, ConfigFile), &ds); err != nil {
+			return err
+		}
+		if ds.DefaultRule, err = readOptionalRaw(filepath.Join(dsDir, DefaultRuleFile)); err != nil {
+			return err
+		}
+		if ds.Collections, err = readCollections(dsDir); err != nil {
+			return err
+		}
+
+		app.DataSources = append(app.DataSources, ds)
+	}
+	return nil
+}
+
+func readCollections(dsDir string) ([]Collection, error) {
+	databases, err := readDirs(dsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var collections []Collection
+	for _, db := range databases {
+		names, err := readDirs(filepath.Join(dsDir, db))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range names {
+			collDir := filepath.Join(dsDir, db, name)
+			c := Collection{Database: db, Collection: name}
+			if c.Rules, err = readOptionalRaw(filepath.Join(collDir, RulesFile)); err != nil {
+				return nil, err
+			}
+			if c.Schema, err = readOptionalRaw(filepath.Join(collDir, SchemaFile)); err != nil {
+				return nil, err
+			}
+			if c.Relationships, err = readOptionalRaw(filepath.Join(collDir, RelationshipsFile)); err != nil {
+				return nil, err
+			}
+			collections = append(collections, c)
+		}
+	}
+	return collections, nil
+}
+
+func writeDataSources(dir string, app *App) error {
+	for i := range app.DataSources {
+		ds := &app.DataSources[i]
+		if ds.Name == "" {
+			return fmt.Errorf("appconfig: data source %d has no name", i)
+		}
+		if err := checkFileName("data source", ds.Name); err != nil {
+			return err
+		}
+
+		dsDir := filepath.Join(dir, DataSourcesDir, ds.Name)
+		if err := writeJSON(filepath.Join(dsDir, ConfigFile), ds); err != nil {
+			return err
+		}
+		if err := writeRaw(filepath.Join(dsDir, DefaultRuleFile), ds.DefaultRule); err != nil {
+			return err
+		}
+
+		for _, c := range ds.Collections {
+			if err := checkFileName("database", c.Database); err != nil {
+				return err
+			}
+			if err := checkFileName("collection", c.Collection); err != nil {
+				return err
+			}
+			collDir := filepath.Join(dsDir, c.Database, c.Collection)
+			if err := os.MkdirAll(collDir, dirPerm); err != nil {
+				return err
+			}
+			if err := writeRaw(filepath.Join(collDir, RulesFile), c.Rules); err != nil {
+				return err
+			}
+			if err := writeRaw(filepath.Join(collDir, SchemaFile), c.Schema); err != nil {
+				return err
+			}
+			if err := writeRaw(filepath.Join(collDir, RelationshipsFile), c.Relationships); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func readAuth(dir string, app *App) error {
+	root := filepath.Join(dir, AuthDir)
+	if err := readOptionalJSON(filepath.Join(root, ProvidersFile), &app.Auth.Providers); err != nil {
+		return err
+	}
+
+	var cud CustomUserData
+	path := filepath.Join(root, CustomUserDataFile)
+	if err := readJSON(path, &cud); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	app.Auth.CustomUserData = &cud
+	return nil
+}
+
+func writeAuth(dir string, app *App) error {
+	root := filepath.Join(dir, AuthDir)
+	if app.Auth.Providers != nil {
+		if err := writeJSON(filepath.Join(root, ProvidersFile), app.Auth.Providers); err != nil {
+			return err
+		}
+	}
+	if app.Auth.CustomUserData != nil {
+		return writeJSON(filepath.Join(root, CustomUserDataFile), app.Auth.CustomUserData)
+	}
+	return nil
+}
+
+func readEnvironments(dir string, app *App) error {
+	return readEach(filepath.Join(dir, EnvironmentsDir), func(path string) error {
+		var env Environment
+		if err := readJSON(path, &env); err != nil {
+			return err
+		}
+		if app.Environments == nil {
+			app.Environments = map[string]Environment{}
+		}
+		app.Environments[strings.TrimSuffix(filepath.Base(path), jsonExt)] = env
+		return nil
+	})
+}
+
+func writeEnvironments(dir string, app *App) error {
+	names := make([]string, 0, len(app.Environments))
+	for name := range app.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := checkFileName("environment", name); err != nil {
+			return err
+		}
+		if err := writeJSON(filepath.Join(dir, EnvironmentsDir, name+jsonExt), app.Environments[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readValues(dir string, app *App) error {
+	return readEach(filepath.Join(dir, ValuesDir), func(path string) error {
+		var v Value
+		if err := readJSON(path, &v); err != nil {
+			return err
+		}
+		app.Values = append(app.Values, v)
+		return nil
+	})
+}
+
+func writeValues(dir string, app *App) error {
+	for i := range app.Values {
+		v := &app.Values[i]
+		if v.Name == "" {
+			return fmt.Errorf("appconfig: value %d has no name", i)
+		}
+		if err := checkFileName("value", v.Name); err != nil {
+			return err
+		}
+		if err := writeJSON(filepath.Join(dir, ValuesDir, v.Name+jsonExt), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readHTTPEndpoints(dir string, app *App) error {
+	return readOptionalJSON(filepath.Join(dir, HTTPEndpointsDir, ConfigFile), &app.HTTPEndpoints)
+}
+
+func writeHTTPEndpoints(dir string, app *App) error {
+	if len(app.HTTPEndpoints) == 0 {
+		return nil
+	}
+	return writeJSON(filepath.Join(dir, HTTPEndpointsDir, ConfigFile), app.HTTPEndpoints)
+}
+
+// checkFileName returns an error unless name, which names a file or directory
+// of the app directory, is a single local path element.
+func checkFileName(kind, name string) error {
+	if !filepath.IsLocal(name) || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("appconfig: %s %q: name is not a valid file name", kind, name)
+	}
+	return nil
+}
+
+// readEach calls fn for every JSON file of dir, in lexical order. A missing dir is not an error.
+func readEach(dir string, fn func(string) error) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != jsonExt {
+			continue
+		}
+		if err := fn(filepath.Join(dir, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readDirs returns the names of the subdirectories of dir, in lexical order. A missing dir is not an error.
+func readDirs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+func readJSON(path string, v interface{}) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("appconfig: %s: %w", path, err)
+	}
+	return nil
+}
+
+func readOptionalJSON(path string, v interface{}) error {
+	err := readJSON(path, v)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func readOptionalRaw(path string) (json.RawMessage, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(b) {
+		return nil, fmt.Errorf("appconfig: %s: invalid JSON", path)
+	}
+	return b, nil
+}
+
+func writeJSON(path string, v interface{}) error {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return writeFile(path, buf.Bytes())
+}
+
+func writeRaw(path string, raw json.RawMessage) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	buf := &bytes.Buffer{}
+	if err := json.Indent(buf, raw, "", "    "); err != nil {
+		return fmt.Errorf("appconfig: %s: %w", path, err)
+	}
+	buf.WriteByte('\n')
+	return writeFile(path, buf.Bytes())
+}
+
+func writeFile(path string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, filePerm)
+}
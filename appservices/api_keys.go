@@ -0,0 +1,208 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	atlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	apiKeysBasePath = appsBasePath + "/%s/api_keys"
+)
+
+// APIKeysService provides access to the user API keys of the api-key authentication provider in the Realm API.
+//
+// The API cannot update a key; rotate a key by creating a new one and deleting the old one.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/apikeys
+type APIKeysService interface {
+	List(context.Context, string, string) ([]APIKey, *Response, error)
+	Get(context.Context, string, string, string) (*APIKey, *Response, error)
+	Create(context.Context, string, string, *APIKeyRequest) (*APIKey, *Response, error)
+	Delete(context.Context, string, string, string) (*Response, error)
+	Enable(context.Context, string, string, string) (*Response, error)
+	Disable(context.Context, string, string, string) (*Response, error)
+}
+
+// APIKeysServiceOp provides an implementation of the APIKeysService interface.
+type APIKeysServiceOp service
+
+var _ APIKeysService = &APIKeysServiceOp{}
+
+// List all user API keys. The key values are not returned.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/apikeys
+func (s *APIKeysServiceOp) List(ctx context.Context, groupID, appID string) ([]APIKey, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	path := fmt.Sprintf(apiKeysBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var root []APIKey
+	resp, err := s.Client.Do(ctx, req, &root)
+
+	return root, resp, err
+}
+
+// Get retrieves a single user API key. The key value is not returned.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/apikeys
+func (s *APIKeysServiceOp) Get(ctx context.Context, groupID, appID, keyID string) (*APIKey, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if keyID == "" {
+		return nil, nil, atlas.NewArgError("keyID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(apiKeysBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, keyID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(APIKey)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Create creates a user API key. The returned APIKey.Key is the only time the
+// key value is available; the response is neither copied to Response.Raw nor
+// exposed to the OnRequestCompleted callback.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/apikeys
+func (s *APIKeysServiceOp) Create(ctx context.Context, groupID, appID string, createRequest *APIKeyRequest) (*APIKey, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if createRequest == nil {
+		return nil, nil, atlas.NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf(apiKeysBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(APIKey)
+	resp, err := s.Client.Do(withSensitiveBody(ctx), req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Delete deletes a user API key.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/apikeys
+func (s *APIKeysServiceOp) Delete(ctx context.Context, groupID, appID, keyID string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if keyID == "" {
+		return nil, atlas.NewArgError("keyID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(apiKeysBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, keyID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Enable enables a user API key.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/apikeys
+func (s *APIKeysServiceOp) Enable(ctx context.Context, groupID, appID, keyID string) (*Response, error) {
+	return s.setEnabled(ctx, groupID, appID, keyID, "enable")
+}
+
+// Disable disables a user API key. Logging in with a disabled key fails.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/apikeys
+func (s *APIKeysServiceOp) Disable(ctx context.Context, groupID, appID, keyID string) (*Response, error) {
+	return s.setEnabled(ctx, groupID, appID, keyID, "disable")
+}
+
+func (s *APIKeysServiceOp) setEnabled(ctx context.Context, groupID, appID, keyID, action string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if keyID == "" {
+		return nil, atlas.NewArgError("keyID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(apiKeysBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s/%s", basePath, keyID, action)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// APIKey represents a user API key.
+type APIKey struct {
+	ID   string `json:"_id,omitempty"`
+	Name string `json:"name,omitempty"`
+	// Key is only set in the response of APIKeysService.Create.
+	Key      SensitiveString `json:"key,omitempty"`
+	Disabled bool            `json:"disabled,omitempty"`
+}
+
+// APIKeyRequest represents a request to create a user API key.
+type APIKeyRequest struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,188 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestAPIKeys_List(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/api_keys", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[{"_id": "1", "name": "billing", "disabled": false}, {"_id": "2", "name": "old", "disabled": true}]`)
+	})
+
+	keys, _, err := client.APIKeys.List(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("APIKeys.List returned error: %v", err)
+	}
+
+	expected := []APIKey{
+		{ID: "1", Name: "billing"},
+		{ID: "2", Name: "old", Disabled: true},
+	}
+
+	if diff := deep.Equal(keys, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestAPIKeys_Get(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	keyID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/api_keys/%s", groupID, appID, keyID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"_id": "1", "name": "billing", "disabled": false}`)
+	})
+
+	key, _, err := client.APIKeys.Get(ctx, groupID, appID, keyID)
+	if err != nil {
+		t.Fatalf("APIKeys.Get returned error: %v", err)
+	}
+
+	expected := &APIKey{ID: "1", Name: "billing"}
+
+	if diff := deep.Equal(key, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestAPIKeys_Create(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	client.withRaw = true
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/api_keys", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		expected := map[string]interface{}{"name": "billing"}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		fmt.Fprint(w, `{"_id": "1", "name": "billing", "key": "k3yv4lu3", "disabled": false}`)
+	})
+
+	client.OnRequestCompleted(func(_ *http.Request, resp *http.Response) {
+		if b, _ := io.ReadAll(resp.Body); strings.Contains(string(b), "k3yv4lu3") {
+			t.Errorf("OnRequestCompleted exposed the key: %s", b)
+		}
+		// The request carries no secret, but must be redacted like the response.
+		testRedactedRequest(t, resp.Request, "billing")
+	})
+
+	key, resp, err := client.APIKeys.Create(ctx, groupID, appID, &APIKeyRequest{Name: "billing"})
+	if err != nil {
+		t.Fatalf("APIKeys.Create returned error: %v", err)
+	}
+
+	expected := &APIKey{ID: "1", Name: "billing", Key: "k3yv4lu3"}
+
+	if diff := deep.Equal(key, expected); diff != nil {
+		t.Error(diff)
+	}
+	if resp.Raw != nil {
+		t.Errorf("Response.Raw = %s, expected nil", resp.Raw)
+	}
+	if s := fmt.Sprintf("%v %+v", key, *key); strings.Contains(s, "k3yv4lu3") {
+		t.Errorf("printing the key exposed its value: %s", s)
+	}
+}
+
+func TestAPIKeys_Delete(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	keyID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/api_keys/%s", groupID, appID, keyID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.APIKeys.Delete(ctx, groupID, appID, keyID)
+	if err != nil {
+		t.Fatalf("APIKeys.Delete returned error: %v", err)
+	}
+}
+
+func TestAPIKeys_EnableDisable(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	keyID := "1"
+
+	basePath := fmt.Sprintf("/groups/%s/apps/%s/api_keys/%s", groupID, appID, keyID)
+
+	var calls []string
+	for _, action := range []string{"enable", "disable"} {
+		mux.HandleFunc(basePath+"/"+action, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodPut)
+			calls = append(calls, action)
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+
+	if _, err := client.APIKeys.Enable(ctx, groupID, appID, keyID); err != nil {
+		t.Fatalf("APIKeys.Enable returned error: %v", err)
+	}
+	if _, err := client.APIKeys.Disable(ctx, groupID, appID, keyID); err != nil {
+		t.Fatalf("APIKeys.Disable returned error: %v", err)
+	}
+
+	if diff := deep.Equal(calls, []string{"enable", "disable"}); diff != nil {
+		t.Error(diff)
+	}
+}
@@ -17,6 +17,7 @@ package appservices
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 
 	atlas "go.mongodb.org/atlas/mongodbatlas"
@@ -31,6 +32,12 @@ const (
 // See more: https://docs.mongodb.com/realm/admin/api/v3/#application-level-apis
 type AppsService interface {
 	List(context.Context, string, *ApplicationListOptions) ([]Application, *Response, error)
+	Get(context.Context, string, string) (*Application, *Response, error)
+	Create(context.Context, string, *ApplicationRequest) (*Application, *Response, error)
+	Update(context.Context, string, string, *ApplicationRequest) (*Application, *Response, error)
+	Delete(context.Context, string, string) (*Response, error)
+	Export(context.Context, string, string, *ExportOptions, io.Writer) (*Response, error)
+	Import(context.Context, string, string, *ImportOptions, io.Reader) (*Response, error)
 }
 
 // AppsServiceOp provides an implementation of the AppsService interface.
@@ -61,16 +68,232 @@ func (s *AppsServiceOp) List(ctx context.Context, groupID string, opts *Applicat
 	return root, resp, err
 }
 
+// Get retrieves a single Realm app.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/apps
+func (s *AppsServiceOp) Get(ctx context.Context, groupID, appID string) (*Application, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(appsBasePath, groupID)
+	path := fmt.Sprintf("%s/%s", basePath, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Application)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Create creates a new Realm app within an Atlas project/group.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/apps
+func (s *AppsServiceOp) Create(ctx context.Context, groupID string, createRequest *ApplicationRequest) (*Application, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if createRequest == nil {
+		return nil, nil, atlas.NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf(appsBasePath, groupID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Application)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Update updates the name, location, deployment model or environment of a Realm app.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/apps
+func (s *AppsServiceOp) Update(ctx context.Context, groupID, appID string, updateRequest *ApplicationRequest) (*Application, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if updateRequest == nil {
+		return nil, nil, atlas.NewArgError("updateRequest", "cannot be nil")
+	}
+
+	basePath := fmt.Sprintf(appsBasePath, groupID)
+	path := fmt.Sprintf("%s/%s", basePath, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPatch, path, updateRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Application)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Delete deletes a Realm app.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/apps
+func (s *AppsServiceOp) Delete(ctx context.Context, groupID, appID string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(appsBasePath, groupID)
+	path := fmt.Sprintf("%s/%s", basePath, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Export streams a ZIP archive of the app configuration into w.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/apps
+func (s *AppsServiceOp) Export(ctx context.Context, groupID, appID string, opts *ExportOptions, w io.Writer) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if w == nil {
+		return nil, atlas.NewArgError("w", "cannot be nil")
+	}
+
+	basePath := fmt.Sprintf(appsBasePath, groupID)
+	path, err := setQueryParams(fmt.Sprintf("%s/%s/export", basePath, appID), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", zipMediaType)
+
+	return s.Client.Do(ctx, req, w)
+}
+
+// Import uploads a ZIP archive of an app configuration, as produced by Export, and deploys it.
+// To review the changes before deploying them, make them in a draft and use DraftsService.Diff.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/apps
+func (s *AppsServiceOp) Import(ctx context.Context, groupID, appID string, opts *ImportOptions, archive io.Reader) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if archive == nil {
+		return nil, atlas.NewArgError("archive", "cannot be nil")
+	}
+
+	basePath := fmt.Sprintf(appsBasePath, groupID)
+	path, err := setQueryParams(fmt.Sprintf("%s/%s/import", basePath, appID), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := multipartRequestDoer(s.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := client.NewMultipartRequest(ctx, http.MethodPost, path, "file", "app.zip", archive)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
 type ApplicationListOptions struct {
 	Product string `url:"product,omitempty"`
 }
 
+// Application represents a Realm app.
 type Application struct {
 	ID              string `json:"_id,omitempty"`
 	ClientAppID     string `json:"client_app_id,omitempty"`
 	Name            string `json:"name,omitempty"`
 	Location        string `json:"location,omitempty"`
 	DeploymentModel string `json:"deployment_model,omitempty"`
+	ProviderRegion  string `json:"provider_region,omitempty"`
 	DomainID        string `json:"domain_id,omitempty"`
 	GroupID         string `json:"group_id,omitempty"`
+	Product         string `json:"product,omitempty"`
+	Environment     string `json:"environment,omitempty"`
+	LastUsed        *int64 `json:"last_used,omitempty"`
+	LastModified    *int64 `json:"last_modified,omitempty"`
+}
+
+// ExportOptions specifies the optional parameters to the Export method.
+type ExportOptions struct {
+	// Deployment exports the configuration of a specific deployment instead of the latest one.
+	Deployment string `url:"deployment,omitempty"`
+	// SourceControl exports the configuration in the format used by GitHub automatic deployment.
+	SourceControl bool `url:"source_control,omitempty"`
+	// Template exports the configuration without any environment specific identifiers.
+	Template bool `url:"template,omitempty"`
+}
+
+// ImportOptions specifies the optional parameters to the Import method.
+type ImportOptions struct {
+	// Strategy is one of "merge", "replace" or "replace-by-name".
+	Strategy string `url:"strategy,omitempty"`
+}
+
+// ApplicationRequest represents a request to create or update a Realm app.
+type ApplicationRequest struct {
+	DataSource      *ApplicationDataSource `json:"data_source,omitempty"`
+	Name            string                 `json:"name,omitempty"`
+	Location        string                 `json:"location,omitempty"`
+	DeploymentModel string                 `json:"deployment_model,omitempty"`
+	ProviderRegion  string                 `json:"provider_region,omitempty"`
+	Environment     string                 `json:"environment,omitempty"`
+	TemplateID      string                 `json:"template_id,omitempty"`
+}
+
+// ApplicationDataSource represents the Atlas cluster linked to a new Realm app.
+type ApplicationDataSource struct {
+	Name   string                      `json:"name,omitempty"`
+	Type   string                      `json:"type,omitempty"`
+	Config ApplicationDataSourceConfig `json:"config"`
+}
+
+// ApplicationDataSourceConfig represents the configuration of a linked data source.
+type ApplicationDataSourceConfig struct {
+	ClusterName string `json:"clusterName,omitempty"`
 }
@@ -15,13 +15,24 @@
 package appservices
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/go-test/deep"
+	atlas "go.mongodb.org/atlas/mongodbatlas"
 )
 
+// requestDoer hides the multipart support of a Client.
+type requestDoer struct {
+	atlas.RequestDoer
+}
+
 func TestAppsServiceOp_List(t *testing.T) {
 	client, mux, teardown := setup()
 	defer teardown()
@@ -64,3 +75,294 @@ func TestAppsServiceOp_List(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func TestAppsServiceOp_Get(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{
+		"_id": "5c7498dg87d9e6526801572b",
+		"client_app_id": "name-abcde",
+		"name": "name",
+		"location": "US-VA",
+		"deployment_model": "GLOBAL",
+		"provider_region": "aws-us-east-1",
+		"domain_id": "3",
+		"group_id": "6c7498dg87d9e6526801572b",
+		"environment": "development",
+		"last_used": 1615840000,
+		"last_modified": 1615830000
+	  }`)
+	})
+
+	app, _, err := client.Apps.Get(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("Apps.Get returned error: %v", err)
+	}
+
+	expected := &Application{
+		ID:              appID,
+		ClientAppID:     "name-abcde",
+		Name:            "name",
+		Location:        "US-VA",
+		DeploymentModel: "GLOBAL",
+		ProviderRegion:  "aws-us-east-1",
+		DomainID:        "3",
+		GroupID:         groupID,
+		Environment:     "development",
+		LastUsed:        pointer[int64](1615840000),
+		LastModified:    pointer[int64](1615830000),
+	}
+
+	if diff := deep.Equal(app, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestAppsServiceOp_Create(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+
+	createRequest := &ApplicationRequest{
+		Name:            "name",
+		Location:        "US-VA",
+		DeploymentModel: "GLOBAL",
+		ProviderRegion:  "aws-us-east-1",
+		Environment:     "production",
+		TemplateID:      "flex-sync-guides.todo",
+		DataSource: &ApplicationDataSource{
+			Name: "mongodb-atlas",
+			Type: "mongodb-atlas",
+			Config: ApplicationDataSourceConfig{
+				ClusterName: "Cluster0",
+			},
+		},
+	}
+
+	path := fmt.Sprintf("/groups/%s/apps", groupID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		expected := map[string]interface{}{
+			"name":             "name",
+			"location":         "US-VA",
+			"deployment_model": "GLOBAL",
+			"provider_region":  "aws-us-east-1",
+			"environment":      "production",
+			"template_id":      "flex-sync-guides.todo",
+			"data_source": map[string]interface{}{
+				"name": "mongodb-atlas",
+				"type": "mongodb-atlas",
+				"config": map[string]interface{}{
+					"clusterName": "Cluster0",
+				},
+			},
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		fmt.Fprint(w, `{
+		"_id": "5c7498dg87d9e6526801572b",
+		"client_app_id": "name-abcde",
+		"name": "name",
+		"location": "US-VA",
+		"deployment_model": "GLOBAL",
+		"provider_region": "aws-us-east-1",
+		"group_id": "6c7498dg87d9e6526801572b"
+	  }`)
+	})
+
+	app, _, err := client.Apps.Create(ctx, groupID, createRequest)
+	if err != nil {
+		t.Fatalf("Apps.Create returned error: %v", err)
+	}
+
+	expected := &Application{
+		ID:              "5c7498dg87d9e6526801572b",
+		ClientAppID:     "name-abcde",
+		Name:            "name",
+		Location:        "US-VA",
+		DeploymentModel: "GLOBAL",
+		ProviderRegion:  "aws-us-east-1",
+		GroupID:         groupID,
+	}
+
+	if diff := deep.Equal(app, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestAppsServiceOp_Update(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	updateRequest := &ApplicationRequest{
+		Name: "new-name",
+	}
+
+	path := fmt.Sprintf("/groups/%s/apps/%s", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPatch)
+		expected := map[string]interface{}{
+			"name": "new-name",
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		fmt.Fprint(w, `{
+		"_id": "5c7498dg87d9e6526801572b",
+		"name": "new-name",
+		"group_id": "6c7498dg87d9e6526801572b"
+	  }`)
+	})
+
+	app, _, err := client.Apps.Update(ctx, groupID, appID, updateRequest)
+	if err != nil {
+		t.Fatalf("Apps.Update returned error: %v", err)
+	}
+
+	expected := &Application{
+		ID:      appID,
+		Name:    "new-name",
+		GroupID: groupID,
+	}
+
+	if diff := deep.Equal(app, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestAppsServiceOp_Delete(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Apps.Delete(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("Apps.Delete returned error: %v", err)
+	}
+}
+
+func TestAppsServiceOp_Export(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	client.withRaw = true
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/export", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if got := r.Header.Get("Accept"); got != zipMediaType {
+			t.Errorf("Accept = %v, expected %v", got, zipMediaType)
+		}
+		if got := r.URL.Query().Get("deployment"); got != "1" {
+			t.Errorf("deployment = %v, expected %v", got, "1")
+		}
+		w.Header().Set("Content-Type", zipMediaType)
+		fmt.Fprint(w, "PK\x03\x04archive")
+	})
+
+	var buf bytes.Buffer
+	resp, err := client.Apps.Export(ctx, groupID, appID, &ExportOptions{Deployment: "1"}, &buf)
+	if err != nil {
+		t.Fatalf("Apps.Export returned error: %v", err)
+	}
+
+	if got, expected := buf.String(), "PK\x03\x04archive"; got != expected {
+		t.Errorf("Apps.Export wrote %q, expected %q", got, expected)
+	}
+	if resp.Raw != nil {
+		t.Errorf("Apps.Export buffered the archive in Response.Raw")
+	}
+}
+
+func TestAppsServiceOp_Import(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/import", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		if got := r.URL.Query().Get("strategy"); got != "replace-by-name" {
+			t.Errorf("strategy = %v, expected %v", got, "replace-by-name")
+		}
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("FormFile: %v", err)
+		}
+		defer file.Close()
+
+		if header.Filename != "app.zip" {
+			t.Errorf("Filename = %v, expected %v", header.Filename, "app.zip")
+		}
+		b, _ := io.ReadAll(file)
+		if string(b) != "PK\x03\x04archive" {
+			t.Errorf("file = %q, expected %q", b, "PK\x03\x04archive")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Apps.Import(ctx, groupID, appID, &ImportOptions{Strategy: "replace-by-name"}, strings.NewReader("PK\x03\x04archive"))
+	if err != nil {
+		t.Fatalf("Apps.Import returned error: %v", err)
+	}
+}
+
+func TestAppsServiceOp_Import_multipartUnsupported(t *testing.T) {
+	client, _, teardown := setup()
+	defer teardown()
+
+	apps := &AppsServiceOp{Client: requestDoer{client}}
+
+	_, err := apps.Import(ctx, "6c7498dg87d9e6526801572b", "5c7498dg87d9e6526801572b", nil, strings.NewReader("PK\x03\x04archive"))
+	if !errors.Is(err, ErrMultipartUnsupported) {
+		t.Errorf("Apps.Import returned %v, expected %v", err, ErrMultipartUnsupported)
+	}
+}
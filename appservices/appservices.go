@@ -22,10 +22,12 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"reflect"
 	"strings"
+	"sync"
 
 	"go.mongodb.org/atlas/mongodbatlas"
 
@@ -39,6 +41,7 @@ const (
 	APIAdminV3Path = "api/admin/v3.0/"
 	defaultBaseURL = URL + APIAdminV3Path
 	jsonMediaType  = "application/json"
+	zipMediaType   = "application/zip"
 	userAgent      = "go-realm"
 )
 
@@ -51,8 +54,24 @@ type (
 type Client struct {
 	client             *http.Client
 	BaseURL            *url.URL
+	APIKeys            APIKeysService
 	Apps               AppsService
+	AuthProviders      AuthProvidersService
+	CustomUserData     CustomUserDataService
+	Dependencies       DependenciesService
+	DeploymentConfig   DeploymentConfigService
+	Deployments        DeploymentsService
+	Drafts             DraftsService
+	Environments       EnvironmentsService
 	EventTriggers      EventTriggersService
+	Functions          FunctionsService
+	Rules              RulesService
+	Schemas            SchemasService
+	Secrets            SecretsService
+	Services           ServicesService
+	UserRegistrations  UserRegistrationsService
+	Users              UsersService
+	Values             ValuesService
 	onRequestCompleted RequestCompletionCallback
 	UserAgent          string
 
@@ -60,10 +79,42 @@ type Client struct {
 	withRaw bool
 }
 
+type sensitiveBodyKey struct{}
+
+// withSensitiveBody marks the request sent with ctx as carrying, or answered
+// with, a body that must not be exposed through Response.Raw or the
+// OnRequestCompleted callback.
+func withSensitiveBody(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sensitiveBodyKey{}, true)
+}
+
+func hasSensitiveBody(ctx context.Context) bool {
+	v, _ := ctx.Value(sensitiveBodyKey{}).(bool)
+	return v
+}
+
 type service struct {
 	Client mongodbatlas.RequestDoer
 }
 
+// MultipartRequestDoer minimum interface for any service of the client that should upload files.
+type MultipartRequestDoer interface {
+	mongodbatlas.RequestDoer
+	NewMultipartRequest(context.Context, string, string, string, string, io.Reader) (*http.Request, error)
+}
+
+// ErrMultipartUnsupported is returned by the uploads of a service whose Client is not a MultipartRequestDoer.
+var ErrMultipartUnsupported = errors.New("client does not support multipart requests")
+
+// multipartRequestDoer returns the MultipartRequestDoer of a service uploading files.
+func multipartRequestDoer(doer mongodbatlas.RequestDoer) (MultipartRequestDoer, error) {
+	m, ok := doer.(MultipartRequestDoer)
+	if !ok {
+		return nil, ErrMultipartUnsupported
+	}
+	return m, nil
+}
+
 // NewClient returns a new Realm API client.
 func NewClient(httpClient *http.Client) *Client {
 	if httpClient == nil {
@@ -78,8 +129,24 @@ func NewClient(httpClient *http.Client) *Client {
 		UserAgent: userAgent,
 	}
 
+	c.APIKeys = &APIKeysServiceOp{Client: c}
 	c.Apps = &AppsServiceOp{Client: c}
+	c.AuthProviders = &AuthProvidersServiceOp{Client: c}
+	c.CustomUserData = &CustomUserDataServiceOp{Client: c}
+	c.Dependencies = &DependenciesServiceOp{Client: c}
+	c.DeploymentConfig = &DeploymentConfigServiceOp{Client: c}
+	c.Deployments = &DeploymentsServiceOp{Client: c}
+	c.Drafts = &DraftsServiceOp{Client: c}
+	c.Environments = &EnvironmentsServiceOp{Client: c}
 	c.EventTriggers = &EventTriggersServiceOp{Client: c}
+	c.Functions = &FunctionsServiceOp{Client: c}
+	c.Rules = &RulesServiceOp{Client: c}
+	c.Schemas = &SchemasServiceOp{Client: c}
+	c.Secrets = &SecretsServiceOp{Client: c}
+	c.Services = &ServicesServiceOp{Client: c}
+	c.UserRegistrations = &UserRegistrationsServiceOp{Client: c}
+	c.Users = &UsersServiceOp{Client: c}
+	c.Values = &ValuesServiceOp{Client: c}
 
 	return c
 }
@@ -146,10 +213,7 @@ func SetUserAgent(ua string) ClientOpt {
 // specified, the value pointed to by body is JSON encoded and included as the
 // request body.
 func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
-	if !strings.HasSuffix(c.BaseURL.Path, "/") {
-		return nil, fmt.Errorf("base URL must have a trailing slash, but %q does not", c.BaseURL)
-	}
-	u, err := c.BaseURL.Parse(urlStr)
+	u, err := c.resolveURL(urlStr)
 	if err != nil {
 		return nil, err
 	}
@@ -180,6 +244,93 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body int
 	return req, nil
 }
 
+// NewRawRequest creates an API request whose body is streamed from body as is,
+// with the given Content-Type. A relative URL can be provided in urlStr, in which
+// case it is resolved relative to the URL of the Client.
+func (c *Client) NewRawRequest(ctx context.Context, method, urlStr, contentType string, body io.Reader) (*http.Request, error) {
+	u, err := c.resolveURL(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Add("Accept", jsonMediaType)
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	return req, nil
+}
+
+// NewMultipartRequest creates an API request that uploads the contents of file
+// as a multipart/form-data part named fieldName. A relative URL can be provided
+// in urlStr, in which case it is resolved relative to the URL of the Client.
+// The file is streamed while the request is sent, so it is never fully buffered in memory.
+func (c *Client) NewMultipartRequest(ctx context.Context, method, urlStr, fieldName, fileName string, file io.Reader) (*http.Request, error) {
+	body := newMultipartBody(fieldName, fileName, file)
+	return c.NewRawRequest(ctx, method, urlStr, body.writer.FormDataContentType(), body)
+}
+
+func (c *Client) resolveURL(urlStr string) (*url.URL, error) {
+	if !strings.HasSuffix(c.BaseURL.Path, "/") {
+		return nil, fmt.Errorf("base URL must have a trailing slash, but %q does not", c.BaseURL)
+	}
+	return c.BaseURL.Parse(urlStr)
+}
+
+// multipartBody encodes a single file as multipart/form-data while it is being read.
+// The encoding goroutine is only started on the first Read, so a request that is
+// never sent does not leak it.
+type multipartBody struct {
+	reader    *io.PipeReader
+	writer    *multipart.Writer
+	pipe      *io.PipeWriter
+	file      io.Reader
+	fieldName string
+	fileName  string
+	once      sync.Once
+}
+
+func newMultipartBody(fieldName, fileName string, file io.Reader) *multipartBody {
+	pr, pw := io.Pipe()
+	return &multipartBody{
+		reader:    pr,
+		writer:    multipart.NewWriter(pw),
+		pipe:      pw,
+		file:      file,
+		fieldName: fieldName,
+		fileName:  fileName,
+	}
+}
+
+func (b *multipartBody) Read(p []byte) (int, error) {
+	b.once.Do(func() {
+		go b.encode()
+	})
+	return b.reader.Read(p)
+}
+
+func (b *multipartBody) Close() error {
+	return b.reader.Close()
+}
+
+func (b *multipartBody) encode() {
+	part, err := b.writer.CreateFormFile(b.fieldName, b.fileName)
+	if err == nil {
+		_, err = io.Copy(part, b.file)
+	}
+	if err == nil {
+		err = b.writer.Close()
+	}
+	b.pipe.CloseWithError(err)
+}
+
 // OnRequestCompleted sets the DO API request completion callback.
 func (c *Client) OnRequestCompleted(rc mongodbatlas.RequestCompletionCallback) {
 	c.onRequestCompleted = rc
@@ -187,7 +338,7 @@ func (c *Client) OnRequestCompleted(rc mongodbatlas.RequestCompletionCallback) {
 
 // Do sends an API request and returns the API response. The API response is JSON decoded and stored in the value
 // pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
-// the raw response will be written to v, without attempting to decode it.
+// the raw response will be streamed to v, without attempting to decode it or copying it to Response.Raw.
 // The provided ctx must be non-nil, if it is nil an error is returned. If it is canceled or times out,
 // ctx.Err() will be returned.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
@@ -209,8 +360,20 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 
 		return nil, err
 	}
+	sensitive := hasSensitiveBody(ctx)
+	if sensitive {
+		// The body was sent, don't let the returned Response replay it.
+		req.GetBody = nil
+		if resp.Request != nil {
+			resp.Request.GetBody = nil
+		}
+	}
 	if c.onRequestCompleted != nil {
-		c.onRequestCompleted(req, resp)
+		if sensitive {
+			c.onRequestCompleted(redactRequest(req), redactResponse(resp))
+		} else {
+			c.onRequestCompleted(req, resp)
+		}
 	}
 
 	defer func() {
@@ -237,7 +400,12 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 
 	body := resp.Body
 
-	if c.withRaw {
+	if w, ok := v.(io.Writer); ok {
+		_, err = io.Copy(w, body)
+		return response, err
+	}
+
+	if c.withRaw && !sensitive {
 		raw := new(bytes.Buffer)
 		_, err = io.Copy(raw, body)
 		if err != nil {
@@ -249,21 +417,37 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 	}
 
 	if v != nil {
-		if w, ok := v.(io.Writer); ok {
-			_, _ = io.Copy(w, body)
-		} else {
-			decErr := json.NewDecoder(body).Decode(v)
-			if errors.Is(decErr, io.EOF) {
-				decErr = nil // ignore EOF errors caused by empty response body
-			}
-			if decErr != nil {
-				err = decErr
-			}
+		decErr := json.NewDecoder(body).Decode(v)
+		if errors.Is(decErr, io.EOF) {
+			decErr = nil // ignore EOF errors caused by empty response body
+		}
+		if decErr != nil {
+			err = decErr
 		}
 	}
 	return response, err
 }
 
+// redactRequest returns a copy of req without its body.
+func redactRequest(req *http.Request) *http.Request {
+	r := req.Clone(req.Context())
+	r.Body = http.NoBody
+	r.GetBody = nil
+	r.ContentLength = 0
+	return r
+}
+
+// redactResponse returns a copy of resp without its body, nor the body of its request.
+func redactResponse(resp *http.Response) *http.Response {
+	r := *resp
+	r.Body = http.NoBody
+	r.ContentLength = 0
+	if r.Request != nil {
+		r.Request = redactRequest(r.Request)
+	}
+	return &r
+}
+
 func setQueryParams(s string, opt interface{}) (string, error) {
 	v := reflect.ValueOf(opt)
 
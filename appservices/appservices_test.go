@@ -20,6 +20,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"net/http/httputil"
@@ -82,6 +84,28 @@ func testMethod(t *testing.T, r *http.Request, expected string) {
 	}
 }
 
+// testRedactedRequest fails t if the body of req, as sent or replayed, contains secret.
+func testRedactedRequest(t *testing.T, req *http.Request, secret string) {
+	t.Helper()
+	if req == nil {
+		t.Error("expected a request")
+		return
+	}
+	if req.GetBody != nil {
+		t.Error("request body can be replayed")
+		if body, err := req.GetBody(); err == nil {
+			if b, _ := io.ReadAll(body); strings.Contains(string(b), secret) {
+				t.Errorf("replayed request body exposes the secret: %s", b)
+			}
+		}
+	}
+	if req.Body != nil {
+		if b, _ := io.ReadAll(req.Body); strings.Contains(string(b), secret) {
+			t.Errorf("request body exposes the secret: %s", b)
+		}
+	}
+}
+
 func testURLParseError(t *testing.T, err error) {
 	t.Helper()
 	if err == nil {
@@ -422,3 +446,66 @@ func TestSetBaseURL_badURL(t *testing.T) {
 
 	testURLParseError(t, err)
 }
+
+func TestNewMultipartRequest(t *testing.T) {
+	c := NewClient(nil)
+
+	req, err := c.NewMultipartRequest(ctx, http.MethodPost, "foo", "file", "app.zip", strings.NewReader("content"))
+	if err != nil {
+		t.Fatalf("NewMultipartRequest returned unexpected error: %v", err)
+	}
+
+	if expected := defaultBaseURL + "foo"; req.URL.String() != expected {
+		t.Errorf("NewMultipartRequest() URL = %v, expected %v", req.URL, expected)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("ParseMediaType returned unexpected error: %v", err)
+	}
+	if mediaType != "multipart/form-data" {
+		t.Errorf("NewMultipartRequest() Content-Type = %v, expected multipart/form-data", mediaType)
+	}
+
+	part, err := multipart.NewReader(req.Body, params["boundary"]).NextPart()
+	if err != nil {
+		t.Fatalf("NextPart returned unexpected error: %v", err)
+	}
+	if part.FormName() != "file" || part.FileName() != "app.zip" {
+		t.Errorf("NewMultipartRequest() part = %v/%v, expected file/app.zip", part.FormName(), part.FileName())
+	}
+	if b, _ := io.ReadAll(part); string(b) != "content" {
+		t.Errorf("NewMultipartRequest() part body = %v, expected content", string(b))
+	}
+}
+
+func TestNewMultipartRequest_closeBeforeRead(t *testing.T) {
+	c := NewClient(nil)
+
+	req, err := c.NewMultipartRequest(ctx, http.MethodPost, "foo", "file", "app.zip", strings.NewReader("content"))
+	if err != nil {
+		t.Fatalf("NewMultipartRequest returned unexpected error: %v", err)
+	}
+	if err := req.Body.Close(); err != nil {
+		t.Fatalf("Close returned unexpected error: %v", err)
+	}
+	if _, err := req.Body.Read(make([]byte, 1)); !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("Read after Close = %v, expected %v", err, io.ErrClosedPipe)
+	}
+}
+
+func TestNewRawRequest(t *testing.T) {
+	c := NewClient(nil)
+
+	req, err := c.NewRawRequest(ctx, http.MethodPut, "foo", "application/gzip", strings.NewReader("content"))
+	if err != nil {
+		t.Fatalf("NewRawRequest returned unexpected error: %v", err)
+	}
+
+	if got := req.Header.Get("Content-Type"); got != "application/gzip" {
+		t.Errorf("NewRawRequest() Content-Type = %v, expected application/gzip", got)
+	}
+	if b, _ := io.ReadAll(req.Body); string(b) != "content" {
+		t.Errorf("NewRawRequest() Body = %v, expected content", string(b))
+	}
+}
@@ -0,0 +1,407 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	atlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	authProvidersBasePath = appsBasePath + "/%s/auth_providers"
+)
+
+// Authentication provider types.
+const (
+	AuthProviderTypeAnonymous      = "anon-user"
+	AuthProviderTypeLocalUserPass  = "local-userpass"
+	AuthProviderTypeAPIKey         = "api-key"
+	AuthProviderTypeCustomToken    = "custom-token"
+	AuthProviderTypeCustomFunction = "custom-function"
+	AuthProviderTypeGoogle         = "oauth2-google"
+	AuthProviderTypeApple          = "oauth2-apple"
+)
+
+// AuthProvidersService provides access to the authentication providers related functions in the Realm API.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/authproviders
+type AuthProvidersService interface {
+	List(context.Context, string, string) ([]AuthProvider, *Response, error)
+	Get(context.Context, string, string, string) (*AuthProvider, *Response, error)
+	Create(context.Context, string, string, *AuthProvider) (*AuthProvider, *Response, error)
+	Update(context.Context, string, string, string, *AuthProvider) (*Response, error)
+	Delete(context.Context, string, string, string) (*Response, error)
+	Enable(context.Context, string, string, string) (*Response, error)
+	Disable(context.Context, string, string, string) (*Response, error)
+}
+
+// AuthProvidersServiceOp provides an implementation of the AuthProvidersService interface.
+type AuthProvidersServiceOp service
+
+var _ AuthProvidersService = &AuthProvidersServiceOp{}
+
+// List all authentication providers. The configuration of each provider is not returned.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/authproviders
+func (s *AuthProvidersServiceOp) List(ctx context.Context, groupID, appID string) ([]AuthProvider, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	path := fmt.Sprintf(authProvidersBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var root []AuthProvider
+	resp, err := s.Client.Do(ctx, req, &root)
+
+	return root, resp, err
+}
+
+// Get retrieves a single authentication provider, including its configuration.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/authproviders
+func (s *AuthProvidersServiceOp) Get(ctx context.Context, groupID, appID, providerID string) (*AuthProvider, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if providerID == "" {
+		return nil, nil, atlas.NewArgError("providerID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(authProvidersBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, providerID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(AuthProvider)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Create creates an authentication provider.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/authproviders
+func (s *AuthProvidersServiceOp) Create(ctx context.Context, groupID, appID string, createRequest *AuthProvider) (*AuthProvider, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if createRequest == nil {
+		return nil, nil, atlas.NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf(authProvidersBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(AuthProvider)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Update updates an authentication provider.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/authproviders
+func (s *AuthProvidersServiceOp) Update(ctx context.Context, groupID, appID, providerID string, updateRequest *AuthProvider) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if providerID == "" {
+		return nil, atlas.NewArgError("providerID", "must be set")
+	}
+	if updateRequest == nil {
+		return nil, atlas.NewArgError("updateRequest", "cannot be nil")
+	}
+
+	basePath := fmt.Sprintf(authProvidersBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, providerID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPatch, path, updateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Delete deletes an authentication provider.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/authproviders
+func (s *AuthProvidersServiceOp) Delete(ctx context.Context, groupID, appID, providerID string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if providerID == "" {
+		return nil, atlas.NewArgError("providerID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(authProvidersBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, providerID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Enable enables an authentication provider.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/authproviders
+func (s *AuthProvidersServiceOp) Enable(ctx context.Context, groupID, appID, providerID string) (*Response, error) {
+	return s.setEnabled(ctx, groupID, appID, providerID, "enable")
+}
+
+// Disable disables an authentication provider. Users of a disabled provider cannot log in.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/authproviders
+func (s *AuthProvidersServiceOp) Disable(ctx context.Context, groupID, appID, providerID string) (*Response, error) {
+	return s.setEnabled(ctx, groupID, appID, providerID, "disable")
+}
+
+func (s *AuthProvidersServiceOp) setEnabled(ctx context.Context, groupID, appID, providerID, action string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if providerID == "" {
+		return nil, atlas.NewArgError("providerID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(authProvidersBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s/%s", basePath, providerID, action)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// AuthProvider represents an App Services authentication provider.
+type AuthProvider struct {
+	// Config is the AuthProviderConfig matching Type, and a RawAuthProviderConfig
+	// for any other provider type.
+	Config AuthProviderConfig `json:"config,omitempty"`
+	// SecretConfig references the secrets holding the credentials of the provider.
+	SecretConfig   *AuthProviderSecretConfig `json:"secret_config,omitempty"`
+	Disabled       *bool                     `json:"disabled,omitempty"`
+	LastModified   *int64                    `json:"last_modified,omitempty"`
+	ID             string                    `json:"_id,omitempty"`
+	Name           string                    `json:"name,omitempty"`
+	Type           string                    `json:"type,omitempty"`
+	MetadataFields []MetadataField           `json:"metadata_fields,omitempty"`
+	RedirectURIs   []string                  `json:"redirect_uris,omitempty"`
+}
+
+// NewAuthProvider returns an authentication provider of the type matching config.
+// Providers are named after their type, as App Services requires.
+func NewAuthProvider(config AuthProviderConfig) *AuthProvider {
+	t := config.ProviderType()
+	return &AuthProvider{Name: t, Type: t, Config: config}
+}
+
+// UnmarshalJSON decodes the provider configuration into the type matching the provider type.
+func (p *AuthProvider) UnmarshalJSON(data []byte) error {
+	type authProvider AuthProvider
+	aux := struct {
+		*authProvider
+		Config json.RawMessage `json:"config,omitempty"`
+	}{authProvider: (*authProvider)(p)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	p.Config = nil
+	if len(aux.Config) == 0 || string(aux.Config) == "null" {
+		return nil
+	}
+
+	var config AuthProviderConfig
+	switch p.Type {
+	case AuthProviderTypeAnonymous:
+		config = new(AnonymousAuthConfig)
+	case AuthProviderTypeLocalUserPass:
+		config = new(LocalUserPassAuthConfig)
+	case AuthProviderTypeAPIKey:
+		config = new(APIKeyAuthConfig)
+	case AuthProviderTypeCustomToken:
+		config = new(CustomTokenAuthConfig)
+	case AuthProviderTypeCustomFunction:
+		config = new(CustomFunctionAuthConfig)
+	case AuthProviderTypeGoogle:
+		config = new(GoogleAuthConfig)
+	case AuthProviderTypeApple:
+		config = new(AppleAuthConfig)
+	default:
+		config = &RawAuthProviderConfig{Type: p.Type}
+	}
+	if err := json.Unmarshal(aux.Config, config); err != nil {
+		return fmt.Errorf("decoding %s auth provider config: %w", p.Type, err)
+	}
+	p.Config = config
+
+	return nil
+}
+
+// AuthProviderConfig is implemented by the configuration of each authentication provider type.
+type AuthProviderConfig interface {
+	// ProviderType returns the authentication provider type the configuration applies to.
+	ProviderType() string
+}
+
+// AuthProviderSecretConfig holds the names of the secrets a provider reads its credentials from.
+type AuthProviderSecretConfig struct {
+	// ClientSecret is used by the OAuth 2.0 providers.
+	ClientSecret string `json:"clientSecret,omitempty"`
+	// SigningKeys are used by the custom-token provider when it does not read keys from a JWK URI.
+	SigningKeys []string `json:"signingKeys,omitempty"`
+}
+
+// MetadataField maps a field of the provider's user data to a field of the user metadata.
+type MetadataField struct {
+	Required  bool   `json:"required"`
+	Name      string `json:"name"`
+	FieldName string `json:"field_name,omitempty"`
+}
+
+// AnonymousAuthConfig represents the configuration of the anon-user provider, which has no settings.
+type AnonymousAuthConfig struct{}
+
+// ProviderType returns AuthProviderTypeAnonymous.
+func (*AnonymousAuthConfig) ProviderType() string { return AuthProviderTypeAnonymous }
+
+// LocalUserPassAuthConfig represents the configuration of the local-userpass (email/password) provider.
+type LocalUserPassAuthConfig struct {
+	AutoConfirm              *bool  `json:"autoConfirm,omitempty"`
+	RunConfirmationFunction  *bool  `json:"runConfirmationFunction,omitempty"`
+	RunResetFunction         *bool  `json:"runResetFunction,omitempty"`
+	EmailConfirmationURL     string `json:"emailConfirmationUrl,omitempty"`
+	ConfirmEmailSubject      string `json:"confirmEmailSubject,omitempty"`
+	ConfirmationFunctionName string `json:"confirmationFunctionName,omitempty"`
+	ConfirmationFunctionID   string `json:"confirmationFunctionId,omitempty"`
+	ResetPasswordURL         string `json:"resetPasswordUrl,omitempty"`
+	ResetPasswordSubject     string `json:"resetPasswordSubject,omitempty"`
+	ResetFunctionName        string `json:"resetFunctionName,omitempty"`
+	ResetFunctionID          string `json:"resetFunctionId,omitempty"`
+}
+
+// ProviderType returns AuthProviderTypeLocalUserPass.
+func (*LocalUserPassAuthConfig) ProviderType() string { return AuthProviderTypeLocalUserPass }
+
+// APIKeyAuthConfig represents the configuration of the api-key provider, which has no settings.
+type APIKeyAuthConfig struct{}
+
+// ProviderType returns AuthProviderTypeAPIKey.
+func (*APIKeyAuthConfig) ProviderType() string { return AuthProviderTypeAPIKey }
+
+// CustomTokenAuthConfig represents the configuration of the custom-token (custom JWT) provider.
+// Tokens are verified with the keys of AuthProviderSecretConfig.SigningKeys, or
+// with the keys published at JWKURI when UseJWKURI is set.
+type CustomTokenAuthConfig struct {
+	UseJWKURI          *bool    `json:"useJWKURI,omitempty"`
+	RequireAnyAudience *bool    `json:"requireAnyAudience,omitempty"`
+	JWKURI             string   `json:"jwkURI,omitempty"`
+	SigningAlgorithm   string   `json:"signingAlgorithm,omitempty"`
+	Audience           []string `json:"audience,omitempty"`
+}
+
+// ProviderType returns AuthProviderTypeCustomToken.
+func (*CustomTokenAuthConfig) ProviderType() string { return AuthProviderTypeCustomToken }
+
+// CustomFunctionAuthConfig represents the configuration of the custom-function provider.
+type CustomFunctionAuthConfig struct {
+	AuthFunctionName string `json:"authFunctionName,omitempty"`
+	AuthFunctionID   string `json:"authFunctionId,omitempty"`
+}
+
+// ProviderType returns AuthProviderTypeCustomFunction.
+func (*CustomFunctionAuthConfig) ProviderType() string { return AuthProviderTypeCustomFunction }
+
+// GoogleAuthConfig represents the configuration of the oauth2-google provider.
+type GoogleAuthConfig struct {
+	OpenID   *bool  `json:"openId,omitempty"`
+	ClientID string `json:"clientId,omitempty"`
+}
+
+// ProviderType returns AuthProviderTypeGoogle.
+func (*GoogleAuthConfig) ProviderType() string { return AuthProviderTypeGoogle }
+
+// AppleAuthConfig represents the configuration of the oauth2-apple provider.
+type AppleAuthConfig struct {
+	ClientID string `json:"clientId,omitempty"`
+}
+
+// ProviderType returns AuthProviderTypeApple.
+func (*AppleAuthConfig) ProviderType() string { return AuthProviderTypeApple }
+
+// RawAuthProviderConfig holds the configuration of a provider type without a typed configuration.
+type RawAuthProviderConfig struct {
+	Type   string
+	Config map[string]interface{}
+}
+
+// ProviderType returns the provider type the configuration was decoded for.
+func (c *RawAuthProviderConfig) ProviderType() string { return c.Type }
+
+// MarshalJSON encodes the configuration settings.
+func (c *RawAuthProviderConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Config)
+}
+
+// UnmarshalJSON decodes the configuration settings.
+func (c *RawAuthProviderConfig) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &c.Config)
+}
@@ -0,0 +1,334 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestAuthProviders_List(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/auth_providers", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[
+		  {"_id": "1", "name": "anon-user", "type": "anon-user", "disabled": false},
+		  {"_id": "2", "name": "api-key", "type": "api-key", "disabled": true}
+		]`)
+	})
+
+	providers, _, err := client.AuthProviders.List(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("AuthProviders.List returned error: %v", err)
+	}
+
+	expected := []AuthProvider{
+		{ID: "1", Name: "anon-user", Type: AuthProviderTypeAnonymous, Disabled: pointer(false)},
+		{ID: "2", Name: "api-key", Type: AuthProviderTypeAPIKey, Disabled: pointer(true)},
+	}
+
+	if diff := deep.Equal(providers, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestAuthProviders_Get(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	tests := map[string]struct {
+		body     string
+		expected *AuthProvider
+	}{
+		"local-userpass": {
+			body: `{
+			  "_id": "local-userpass",
+			  "name": "local-userpass",
+			  "type": "local-userpass",
+			  "config": {
+			    "autoConfirm": false,
+			    "emailConfirmationUrl": "https://example.com/confirm",
+			    "confirmEmailSubject": "Confirm your email",
+			    "runResetFunction": true,
+			    "resetFunctionName": "resetFunc"
+			  }
+			}`,
+			expected: &AuthProvider{
+				ID:   "local-userpass",
+				Name: "local-userpass",
+				Type: AuthProviderTypeLocalUserPass,
+				Config: &LocalUserPassAuthConfig{
+					AutoConfirm:          pointer(false),
+					EmailConfirmationURL: "https://example.com/confirm",
+					ConfirmEmailSubject:  "Confirm your email",
+					RunResetFunction:     pointer(true),
+					ResetFunctionName:    "resetFunc",
+				},
+			},
+		},
+		"custom-token": {
+			body: `{
+			  "_id": "custom-token",
+			  "name": "custom-token",
+			  "type": "custom-token",
+			  "config": {"useJWKURI": true, "jwkURI": "https://example.com/jwks", "audience": ["my-app"], "signingAlgorithm": "RS256"},
+			  "metadata_fields": [{"required": true, "name": "email", "field_name": "mail"}]
+			}`,
+			expected: &AuthProvider{
+				ID:   "custom-token",
+				Name: "custom-token",
+				Type: AuthProviderTypeCustomToken,
+				Config: &CustomTokenAuthConfig{
+					UseJWKURI:        pointer(true),
+					JWKURI:           "https://example.com/jwks",
+					Audience:         []string{"my-app"},
+					SigningAlgorithm: "RS256",
+				},
+				MetadataFields: []MetadataField{{Required: true, Name: "email", FieldName: "mail"}},
+			},
+		},
+		"custom-function": {
+			body: `{"_id": "custom-function", "type": "custom-function", "config": {"authFunctionName": "authFunc", "authFunctionId": "3"}}`,
+			expected: &AuthProvider{
+				ID:     "custom-function",
+				Type:   AuthProviderTypeCustomFunction,
+				Config: &CustomFunctionAuthConfig{AuthFunctionName: "authFunc", AuthFunctionID: "3"},
+			},
+		},
+		"oauth2-google": {
+			body: `{
+			  "_id": "oauth2-google",
+			  "type": "oauth2-google",
+			  "config": {"clientId": "google-client", "openId": true},
+			  "secret_config": {"clientSecret": "googleSecret"},
+			  "redirect_uris": ["myapp://auth"]
+			}`,
+			expected: &AuthProvider{
+				ID:           "oauth2-google",
+				Type:         AuthProviderTypeGoogle,
+				Config:       &GoogleAuthConfig{ClientID: "google-client", OpenID: pointer(true)},
+				SecretConfig: &AuthProviderSecretConfig{ClientSecret: "googleSecret"},
+				RedirectURIs: []string{"myapp://auth"},
+			},
+		},
+		"oauth2-apple": {
+			body: `{"_id": "oauth2-apple", "type": "oauth2-apple", "config": {"clientId": "com.example.app"}}`,
+			expected: &AuthProvider{
+				ID:     "oauth2-apple",
+				Type:   AuthProviderTypeApple,
+				Config: &AppleAuthConfig{ClientID: "com.example.app"},
+			},
+		},
+		"anon-user": {
+			body: `{"_id": "anon-user", "type": "anon-user", "config": {}}`,
+			expected: &AuthProvider{
+				ID:     "anon-user",
+				Type:   AuthProviderTypeAnonymous,
+				Config: &AnonymousAuthConfig{},
+			},
+		},
+		"api-key": {
+			body: `{"_id": "api-key", "type": "api-key", "config": {}}`,
+			expected: &AuthProvider{
+				ID:     "api-key",
+				Type:   AuthProviderTypeAPIKey,
+				Config: &APIKeyAuthConfig{},
+			},
+		},
+		"other": {
+			body: `{"_id": "other", "type": "oauth2-facebook", "config": {"clientId": "fb"}}`,
+			expected: &AuthProvider{
+				ID:     "other",
+				Type:   "oauth2-facebook",
+				Config: &RawAuthProviderConfig{Type: "oauth2-facebook", Config: map[string]interface{}{"clientId": "fb"}},
+			},
+		},
+	}
+
+	for providerID, tc := range tests {
+		body := tc.body
+		mux.HandleFunc(fmt.Sprintf("/groups/%s/apps/%s/auth_providers/%s", groupID, appID, providerID), func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodGet)
+			fmt.Fprint(w, body)
+		})
+
+		provider, _, err := client.AuthProviders.Get(ctx, groupID, appID, providerID)
+		if err != nil {
+			t.Fatalf("AuthProviders.Get(%s) returned error: %v", providerID, err)
+		}
+
+		if diff := deep.Equal(provider, tc.expected); diff != nil {
+			t.Errorf("%s: %v", providerID, diff)
+		}
+	}
+}
+
+func TestAuthProviders_Create(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/auth_providers", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		expected := map[string]interface{}{
+			"name": "custom-token",
+			"type": "custom-token",
+			"config": map[string]interface{}{
+				"audience":         []interface{}{"my-app"},
+				"signingAlgorithm": "HS256",
+			},
+			"secret_config": map[string]interface{}{"signingKeys": []interface{}{"jwtKey"}},
+			"metadata_fields": []interface{}{
+				map[string]interface{}{"required": false, "name": "name"},
+			},
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		fmt.Fprint(w, `{"_id": "1", "name": "custom-token", "type": "custom-token"}`)
+	})
+
+	createRequest := NewAuthProvider(&CustomTokenAuthConfig{Audience: []string{"my-app"}, SigningAlgorithm: "HS256"})
+	createRequest.SecretConfig = &AuthProviderSecretConfig{SigningKeys: []string{"jwtKey"}}
+	createRequest.MetadataFields = []MetadataField{{Name: "name"}}
+
+	provider, _, err := client.AuthProviders.Create(ctx, groupID, appID, createRequest)
+	if err != nil {
+		t.Fatalf("AuthProviders.Create returned error: %v", err)
+	}
+
+	expected := &AuthProvider{ID: "1", Name: "custom-token", Type: AuthProviderTypeCustomToken}
+
+	if diff := deep.Equal(provider, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestAuthProviders_Update(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	providerID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/auth_providers/%s", groupID, appID, providerID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPatch)
+		expected := map[string]interface{}{
+			"name":   "local-userpass",
+			"type":   "local-userpass",
+			"config": map[string]interface{}{"autoConfirm": true, "resetPasswordUrl": "https://example.com/reset"},
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	updateRequest := NewAuthProvider(&LocalUserPassAuthConfig{AutoConfirm: pointer(true), ResetPasswordURL: "https://example.com/reset"})
+
+	_, err := client.AuthProviders.Update(ctx, groupID, appID, providerID, updateRequest)
+	if err != nil {
+		t.Fatalf("AuthProviders.Update returned error: %v", err)
+	}
+}
+
+func TestAuthProviders_Delete(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	providerID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/auth_providers/%s", groupID, appID, providerID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.AuthProviders.Delete(ctx, groupID, appID, providerID)
+	if err != nil {
+		t.Fatalf("AuthProviders.Delete returned error: %v", err)
+	}
+}
+
+func TestAuthProviders_EnableDisable(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	providerID := "1"
+
+	basePath := fmt.Sprintf("/groups/%s/apps/%s/auth_providers/%s", groupID, appID, providerID)
+
+	var calls []string
+	for _, action := range []string{"enable", "disable"} {
+		mux.HandleFunc(basePath+"/"+action, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodPut)
+			calls = append(calls, action)
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+
+	if _, err := client.AuthProviders.Enable(ctx, groupID, appID, providerID); err != nil {
+		t.Fatalf("AuthProviders.Enable returned error: %v", err)
+	}
+	if _, err := client.AuthProviders.Disable(ctx, groupID, appID, providerID); err != nil {
+		t.Fatalf("AuthProviders.Disable returned error: %v", err)
+	}
+
+	if diff := deep.Equal(calls, []string{"enable", "disable"}); diff != nil {
+		t.Error(diff)
+	}
+}
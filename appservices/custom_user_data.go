@@ -0,0 +1,105 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	atlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	customUserDataBasePath = appsBasePath + "/%s/custom_user_data"
+)
+
+// CustomUserDataService provides access to the custom user data configuration of an app in the Realm API.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/custom-user-data
+type CustomUserDataService interface {
+	Get(context.Context, string, string) (*CustomUserDataConfig, *Response, error)
+	Update(context.Context, string, string, *CustomUserDataConfig) (*Response, error)
+}
+
+// CustomUserDataServiceOp provides an implementation of the CustomUserDataService interface.
+type CustomUserDataServiceOp service
+
+var _ CustomUserDataService = &CustomUserDataServiceOp{}
+
+// Get retrieves the custom user data configuration.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/custom-user-data
+func (s *CustomUserDataServiceOp) Get(ctx context.Context, groupID, appID string) (*CustomUserDataConfig, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	path := fmt.Sprintf(customUserDataBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(CustomUserDataConfig)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Update updates the custom user data configuration.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/custom-user-data
+func (s *CustomUserDataServiceOp) Update(ctx context.Context, groupID, appID string, updateRequest *CustomUserDataConfig) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if updateRequest == nil {
+		return nil, atlas.NewArgError("updateRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf(customUserDataBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPatch, path, updateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// CustomUserDataConfig represents where App Services reads the custom data of
+// each user from: the document of Database.Collection whose UserIDField matches
+// the ID of the user.
+type CustomUserDataConfig struct {
+	Enabled *bool `json:"enabled,omitempty"`
+	// ServiceID is the Service.ID of the mongodb-atlas data source holding the collection.
+	ServiceID   string `json:"mongo_service_id,omitempty"`
+	Database    string `json:"database_name,omitempty"`
+	Collection  string `json:"collection_name,omitempty"`
+	UserIDField string `json:"user_id_field,omitempty"`
+	// OnUserCreationFunctionName is the function run to create the custom data of new users.
+	OnUserCreationFunctionName string `json:"on_user_creation_function_name,omitempty"`
+}
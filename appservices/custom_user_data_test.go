@@ -0,0 +1,101 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCustomUserData_Get(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/custom_user_data", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{
+		  "enabled": true,
+		  "mongo_service_id": "1",
+		  "database_name": "app",
+		  "collection_name": "users",
+		  "user_id_field": "user_id",
+		  "on_user_creation_function_name": "createUserData"
+		}`)
+	})
+
+	config, _, err := client.CustomUserData.Get(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("CustomUserData.Get returned error: %v", err)
+	}
+
+	expected := &CustomUserDataConfig{
+		Enabled:                    pointer(true),
+		ServiceID:                  "1",
+		Database:                   "app",
+		Collection:                 "users",
+		UserIDField:                "user_id",
+		OnUserCreationFunctionName: "createUserData",
+	}
+
+	if diff := deep.Equal(config, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestCustomUserData_Update(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/custom_user_data", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPatch)
+		expected := map[string]interface{}{
+			"enabled":          false,
+			"mongo_service_id": "1",
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	service := &Service{ID: "1", Type: ServiceTypeMongoDBAtlas}
+
+	_, err := client.CustomUserData.Update(ctx, groupID, appID, &CustomUserDataConfig{Enabled: pointer(false), ServiceID: service.ID})
+	if err != nil {
+		t.Fatalf("CustomUserData.Update returned error: %v", err)
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	atlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	dependenciesBasePath = appsBasePath + "/%s/dependencies"
+)
+
+// Dependencies install statuses.
+const (
+	DependenciesStatusCreated    = "created"
+	DependenciesStatusSuccessful = "successful"
+	DependenciesStatusFailed     = "failed"
+)
+
+// DependenciesService provides access to the npm dependencies related functions in the Realm API.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/dependencies
+type DependenciesService interface {
+	List(context.Context, string, string) (*Dependencies, *Response, error)
+	Upload(context.Context, string, string, string, io.Reader) (*Response, error)
+	Upsert(context.Context, string, string, string, string) (*Response, error)
+	Delete(context.Context, string, string, string) (*Response, error)
+	DeleteAll(context.Context, string, string) (*Response, error)
+	Status(context.Context, string, string) (*DependenciesStatus, *Response, error)
+}
+
+// DependenciesServiceOp provides an implementation of the DependenciesService interface.
+type DependenciesServiceOp service
+
+var _ DependenciesService = &DependenciesServiceOp{}
+
+// List the npm packages installed in an app.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/dependencies
+func (s *DependenciesServiceOp) List(ctx context.Context, groupID, appID string) (*Dependencies, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	path := fmt.Sprintf(dependenciesBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Dependencies)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Upload replaces the installed packages with the node_modules archive read from
+// archive. The archive may be a .tar, .tar.gz, .tgz or .zip file, as told by fileName.
+// The install runs asynchronously, see Status and WaitForDependencies.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/dependencies
+func (s *DependenciesServiceOp) Upload(ctx context.Context, groupID, appID, fileName string, archive io.Reader) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if fileName == "" {
+		return nil, atlas.NewArgError("fileName", "must be set")
+	}
+	if archive == nil {
+		return nil, atlas.NewArgError("archive", "cannot be nil")
+	}
+
+	path := fmt.Sprintf(dependenciesBasePath, groupID, appID)
+
+	client, err := multipartRequestDoer(s.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := client.NewMultipartRequest(ctx, http.MethodPut, path, "file", fileName, archive)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Upsert adds a single npm package, or changes its version if it is already installed.
+// An empty version installs the latest one.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/dependencies
+func (s *DependenciesServiceOp) Upsert(ctx context.Context, groupID, appID, name, version string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if name == "" {
+		return nil, atlas.NewArgError("name", "must be set")
+	}
+
+	basePath := fmt.Sprintf(dependenciesBasePath, groupID, appID)
+	path, err := setQueryParams(fmt.Sprintf("%s/%s", basePath, url.PathEscape(name)), &dependencyOptions{Version: version})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Delete removes a single npm package.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/dependencies
+func (s *DependenciesServiceOp) Delete(ctx context.Context, groupID, appID, name string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if name == "" {
+		return nil, atlas.NewArgError("name", "must be set")
+	}
+
+	basePath := fmt.Sprintf(dependenciesBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, url.PathEscape(name))
+
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// DeleteAll removes every npm package.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/dependencies
+func (s *DependenciesServiceOp) DeleteAll(ctx context.Context, groupID, appID string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	path := fmt.Sprintf(dependenciesBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Status retrieves the status of the latest dependencies install.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/dependencies
+func (s *DependenciesServiceOp) Status(ctx context.Context, groupID, appID string) (*DependenciesStatus, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(dependenciesBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/status", basePath)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(DependenciesStatus)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// WaitForDependencies polls the dependencies install status until it is successful or failed.
+// It returns a *DependenciesError if the install failed.
+func WaitForDependencies(ctx context.Context, s DependenciesService, groupID, appID string, opts *WaitOptions) (*DependenciesStatus, error) {
+	return waitFor(ctx, opts, func(ctx context.Context) (*DependenciesStatus, bool, error) {
+		status, _, err := s.Status(ctx, groupID, appID)
+		if err != nil {
+			return nil, false, err
+		}
+		switch status.Status {
+		case DependenciesStatusSuccessful:
+			return status, true, nil
+		case DependenciesStatusFailed:
+			return status, true, &DependenciesError{Status: status}
+		}
+		return status, false, nil
+	})
+}
+
+type dependencyOptions struct {
+	Version string `url:"version,omitempty"`
+}
+
+// Dependencies represents the npm packages installed in an app.
+type Dependencies struct {
+	LastModified *int64       `json:"last_modified,omitempty"`
+	ID           string       `json:"_id,omitempty"`
+	Location     string       `json:"location,omitempty"`
+	UserID       string       `json:"user_id,omitempty"`
+	Packages     []Dependency `json:"dependencies_list,omitempty"`
+}
+
+// Dependency represents an installed npm package.
+type Dependency struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// DependenciesStatus represents the status of a dependencies install.
+type DependenciesStatus struct {
+	Status        string `json:"status,omitempty"`
+	StatusMessage string `json:"status_message,omitempty"`
+}
+
+// DependenciesError reports a dependencies install that finished with the failed status.
+type DependenciesError struct {
+	Status *DependenciesStatus
+}
+
+func (e *DependenciesError) Error() string {
+	return fmt.Sprintf("dependencies install failed: %s", e.Status.StatusMessage)
+}
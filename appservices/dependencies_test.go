@@ -0,0 +1,204 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+func TestDependencies_List(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/dependencies", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{
+		  "_id": "4c7498dg87d9e6526801572b",
+		  "location": "node_modules.tar.gz",
+		  "user_id": "1c7498dg87d9e6526801572b",
+		  "last_modified": 1615840000,
+		  "dependencies_list": [{"name": "lodash", "version": "4.17.21"}]
+		}`)
+	})
+
+	deps, _, err := client.Dependencies.List(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("Dependencies.List returned error: %v", err)
+	}
+
+	expected := &Dependencies{
+		ID:           "4c7498dg87d9e6526801572b",
+		Location:     "node_modules.tar.gz",
+		UserID:       "1c7498dg87d9e6526801572b",
+		LastModified: pointer[int64](1615840000),
+		Packages:     []Dependency{{Name: "lodash", Version: "4.17.21"}},
+	}
+
+	if diff := deep.Equal(deps, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestDependencies_Upload(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/dependencies", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("FormFile: %v", err)
+		}
+		defer file.Close()
+
+		if header.Filename != "node_modules.tgz" {
+			t.Errorf("Filename = %v, expected %v", header.Filename, "node_modules.tgz")
+		}
+		if b, _ := io.ReadAll(file); string(b) != "archive" {
+			t.Errorf("file = %q, expected %q", b, "archive")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Dependencies.Upload(ctx, groupID, appID, "node_modules.tgz", strings.NewReader("archive"))
+	if err != nil {
+		t.Fatalf("Dependencies.Upload returned error: %v", err)
+	}
+}
+
+func TestDependencies_Upload_multipartUnsupported(t *testing.T) {
+	client, _, teardown := setup()
+	defer teardown()
+
+	dependencies := &DependenciesServiceOp{Client: requestDoer{client}}
+
+	_, err := dependencies.Upload(ctx, "6c7498dg87d9e6526801572b", "5c7498dg87d9e6526801572b", "node_modules.tgz", strings.NewReader("archive"))
+	if !errors.Is(err, ErrMultipartUnsupported) {
+		t.Errorf("Dependencies.Upload returned %v, expected %v", err, ErrMultipartUnsupported)
+	}
+}
+
+func TestDependencies_Upsert(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/dependencies/", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		if expected := "/groups/" + groupID + "/apps/" + appID + "/dependencies/@scope%2Fpkg"; r.URL.EscapedPath() != expected {
+			t.Errorf("path = %v, expected %v", r.URL.EscapedPath(), expected)
+		}
+		if got := r.URL.Query().Get("version"); got != "1.2.3" {
+			t.Errorf("version = %v, expected %v", got, "1.2.3")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Dependencies.Upsert(ctx, groupID, appID, "@scope/pkg", "1.2.3")
+	if err != nil {
+		t.Fatalf("Dependencies.Upsert returned error: %v", err)
+	}
+}
+
+func TestDependencies_Delete(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/dependencies/lodash", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Dependencies.Delete(ctx, groupID, appID, "lodash")
+	if err != nil {
+		t.Fatalf("Dependencies.Delete returned error: %v", err)
+	}
+}
+
+func TestDependencies_DeleteAll(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/dependencies", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Dependencies.DeleteAll(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("Dependencies.DeleteAll returned error: %v", err)
+	}
+}
+
+func TestWaitForDependencies(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/dependencies/status", groupID, appID)
+
+	statuses := []string{DependenciesStatusCreated, DependenciesStatusFailed}
+	polls := 0
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprintf(w, `{"status": %q, "status_message": "npm install failed"}`, statuses[polls])
+		polls++
+	})
+
+	_, err := WaitForDependencies(ctx, client.Dependencies, groupID, appID, &WaitOptions{MinDelay: time.Millisecond})
+
+	var depsErr *DependenciesError
+	if !errors.As(err, &depsErr) {
+		t.Fatalf("WaitForDependencies returned %v, expected a *DependenciesError", err)
+	}
+	if polls != len(statuses) {
+		t.Errorf("WaitForDependencies polled %d times, expected %d", polls, len(statuses))
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	atlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	deployBasePath = appsBasePath + "/%s/deploy"
+)
+
+// DeploymentConfigService provides access to the deployment configuration related functions in the Realm API.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/deploy
+type DeploymentConfigService interface {
+	Get(context.Context, string, string) (*DeploymentConfig, *Response, error)
+	Update(context.Context, string, string, *DeploymentConfig) (*Response, error)
+	ListInstallations(context.Context, string, string) ([]GitHubInstallation, *Response, error)
+}
+
+// DeploymentConfigServiceOp provides an implementation of the DeploymentConfigService interface.
+type DeploymentConfigServiceOp service
+
+var _ DeploymentConfigService = &DeploymentConfigServiceOp{}
+
+// Get retrieves the deployment configuration of an app.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/deploy
+func (s *DeploymentConfigServiceOp) Get(ctx context.Context, groupID, appID string) (*DeploymentConfig, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(deployBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/config", basePath)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(DeploymentConfig)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Update updates the deployment configuration of an app. Fields left unset are not modified.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/deploy
+func (s *DeploymentConfigServiceOp) Update(ctx context.Context, groupID, appID string, updateRequest *DeploymentConfig) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if updateRequest == nil {
+		return nil, atlas.NewArgError("updateRequest", "cannot be nil")
+	}
+
+	basePath := fmt.Sprintf(deployBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/config", basePath)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPatch, path, updateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// ListInstallations lists the GitHub app installations that automatic deployment can use.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/deploy
+func (s *DeploymentConfigServiceOp) ListInstallations(ctx context.Context, groupID, appID string) ([]GitHubInstallation, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(deployBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/installation", basePath)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var root []GitHubInstallation
+	resp, err := s.Client.Do(ctx, req, &root)
+
+	return root, resp, err
+}
+
+// DeploymentConfig represents the deployment configuration of an app.
+type DeploymentConfig struct {
+	AutomaticDeployment *AutomaticDeployment `json:"automatic_deployment,omitempty"`
+	// UIDraftsDisabled makes changes made in the App Services UI deploy immediately instead of being drafted.
+	UIDraftsDisabled *bool  `json:"ui_drafts_disabled,omitempty"`
+	LastModified     *int64 `json:"last_modified,omitempty"`
+}
+
+// AutomaticDeployment represents the settings to deploy an app from a GitHub repository.
+type AutomaticDeployment struct {
+	Enabled         *bool    `json:"enabled,omitempty"`
+	Provider        string   `json:"provider,omitempty"`
+	InstallationIDs []string `json:"installation_ids,omitempty"`
+	// Repository is the full name of the GitHub repository, i.e. "owner/name".
+	Repository string `json:"repository,omitempty"`
+	Branch     string `json:"branch,omitempty"`
+	Directory  string `json:"directory,omitempty"`
+}
+
+// GitHubInstallation represents a GitHub app installation.
+type GitHubInstallation struct {
+	InstallationID string `json:"installation_id,omitempty"`
+	OwnerID        string `json:"owner_id,omitempty"`
+	OwnerName      string `json:"owner_name,omitempty"`
+	OwnerType      string `json:"owner_type,omitempty"`
+}
@@ -0,0 +1,149 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestDeploymentConfig_Get(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/deploy/config", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{
+		  "ui_drafts_disabled": true,
+		  "automatic_deployment": {
+		    "enabled": true,
+		    "provider": "github",
+		    "installation_ids": ["123"],
+		    "repository": "mongodb/app",
+		    "branch": "main",
+		    "directory": "/app"
+		  },
+		  "last_modified": 1615840000
+		}`)
+	})
+
+	config, _, err := client.DeploymentConfig.Get(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("DeploymentConfig.Get returned error: %v", err)
+	}
+
+	expected := &DeploymentConfig{
+		UIDraftsDisabled: pointer(true),
+		AutomaticDeployment: &AutomaticDeployment{
+			Enabled:         pointer(true),
+			Provider:        "github",
+			InstallationIDs: []string{"123"},
+			Repository:      "mongodb/app",
+			Branch:          "main",
+			Directory:       "/app",
+		},
+		LastModified: pointer[int64](1615840000),
+	}
+
+	if diff := deep.Equal(config, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestDeploymentConfig_Update(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/deploy/config", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPatch)
+		expected := map[string]interface{}{
+			"ui_drafts_disabled": false,
+			"automatic_deployment": map[string]interface{}{
+				"enabled": false,
+			},
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.DeploymentConfig.Update(ctx, groupID, appID, &DeploymentConfig{
+		UIDraftsDisabled:    pointer(false),
+		AutomaticDeployment: &AutomaticDeployment{Enabled: pointer(false)},
+	})
+	if err != nil {
+		t.Fatalf("DeploymentConfig.Update returned error: %v", err)
+	}
+}
+
+func TestDeploymentConfig_ListInstallations(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/deploy/installation", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[{
+		  "installation_id": "123",
+		  "owner_id": "456",
+		  "owner_name": "mongodb",
+		  "owner_type": "Organization"
+		}]`)
+	})
+
+	installations, _, err := client.DeploymentConfig.ListInstallations(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("DeploymentConfig.ListInstallations returned error: %v", err)
+	}
+
+	expected := []GitHubInstallation{
+		{
+			InstallationID: "123",
+			OwnerID:        "456",
+			OwnerName:      "mongodb",
+			OwnerType:      "Organization",
+		},
+	}
+
+	if diff := deep.Equal(installations, expected); diff != nil {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	atlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	deploymentsBasePath = appsBasePath + "/%s/deployments"
+)
+
+// Deployment statuses.
+const (
+	DeploymentStatusCreated    = "created"
+	DeploymentStatusPending    = "pending"
+	DeploymentStatusSuccessful = "successful"
+	DeploymentStatusFailed     = "failed"
+)
+
+// DeploymentsService provides access to the deployment history related functions in the Realm API.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/deploy
+type DeploymentsService interface {
+	List(context.Context, string, string, *DeploymentListOptions) ([]Deployment, *Response, error)
+	Get(context.Context, string, string, string) (*Deployment, *Response, error)
+	Redeploy(context.Context, string, string, string) (*Response, error)
+}
+
+// DeploymentsServiceOp provides an implementation of the DeploymentsService interface.
+type DeploymentsServiceOp service
+
+var _ DeploymentsService = &DeploymentsServiceOp{}
+
+// List the most recent deployments of an app, newest first. To get the next page,
+// set DeploymentListOptions.Before to the DeployedAt of the last deployment returned.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/deploy
+func (s *DeploymentsServiceOp) List(ctx context.Context, groupID, appID string, opts *DeploymentListOptions) ([]Deployment, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(deploymentsBasePath, groupID, appID)
+	path, err := setQueryParams(basePath, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var root []Deployment
+	resp, err := s.Client.Do(ctx, req, &root)
+
+	return root, resp, err
+}
+
+// Get retrieves a single deployment.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/deploy
+func (s *DeploymentsServiceOp) Get(ctx context.Context, groupID, appID, deploymentID string) (*Deployment, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if deploymentID == "" {
+		return nil, nil, atlas.NewArgError("deploymentID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(deploymentsBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, deploymentID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Deployment)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Redeploy deploys the configuration of a previous deployment again, which is
+// how an app is rolled back.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/deploy
+func (s *DeploymentsServiceOp) Redeploy(ctx context.Context, groupID, appID, deploymentID string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if deploymentID == "" {
+		return nil, atlas.NewArgError("deploymentID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(deploymentsBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s/redeploy", basePath, deploymentID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// WaitForDeployment polls a deployment until its status is successful or failed.
+// It returns a *DeploymentError if the deployment failed.
+func WaitForDeployment(ctx context.Context, s DeploymentsService, groupID, appID, deploymentID string, opts *WaitOptions) (*Deployment, error) {
+	return waitFor(ctx, opts, func(ctx context.Context) (*Deployment, bool, error) {
+		d, _, err := s.Get(ctx, groupID, appID, deploymentID)
+		if err != nil {
+			return nil, false, err
+		}
+		switch d.Status {
+		case DeploymentStatusSuccessful:
+			return d, true, nil
+		case DeploymentStatusFailed:
+			return d, true, &DeploymentError{Deployment: d}
+		}
+		return d, false, nil
+	})
+}
+
+// DeploymentListOptions specifies the optional parameters to the List method.
+type DeploymentListOptions struct {
+	// Before only returns deployments deployed before this Unix timestamp.
+	Before int64 `url:"before,omitempty"`
+}
+
+// Deployment represents a deployment of an app.
+type Deployment struct {
+	DeployedAt         *int64 `json:"deployed_at,omitempty"`
+	ID                 string `json:"_id,omitempty"`
+	Name               string `json:"name,omitempty"`
+	AppID              string `json:"app_id,omitempty"`
+	DraftID            string `json:"draft_id,omitempty"`
+	UserID             string `json:"user_id,omitempty"`
+	Origin             string `json:"origin,omitempty"`
+	CommitHash         string `json:"commit_hash,omitempty"`
+	Status             string `json:"status,omitempty"`
+	StatusErrorMessage string `json:"status_error_message,omitempty"`
+	DiffURL            string `json:"diff_url,omitempty"`
+	RemoteLocation     string `json:"remote_location,omitempty"`
+}
+
+// DeploymentError reports a deployment that finished with the failed status.
+type DeploymentError struct {
+	Deployment *Deployment
+}
+
+func (e *DeploymentError) Error() string {
+	return fmt.Sprintf("deployment %s failed: %s", e.Deployment.ID, e.Deployment.StatusErrorMessage)
+}
@@ -0,0 +1,203 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+func TestDeployments_List(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/deployments", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if got := r.URL.Query().Get("before"); got != "1615840000" {
+			t.Errorf("before = %v, expected %v", got, "1615840000")
+		}
+		fmt.Fprint(w, `[{
+		  "_id": "1c7498dg87d9e6526801572b",
+		  "app_id": "5c7498dg87d9e6526801572b",
+		  "deployed_at": 1615830000,
+		  "origin": "GITHUB",
+		  "commit_hash": "abc123",
+		  "status": "successful"
+		}]`)
+	})
+
+	deployments, _, err := client.Deployments.List(ctx, groupID, appID, &DeploymentListOptions{Before: 1615840000})
+	if err != nil {
+		t.Fatalf("Deployments.List returned error: %v", err)
+	}
+
+	expected := []Deployment{
+		{
+			ID:         "1c7498dg87d9e6526801572b",
+			AppID:      appID,
+			DeployedAt: pointer[int64](1615830000),
+			Origin:     "GITHUB",
+			CommitHash: "abc123",
+			Status:     DeploymentStatusSuccessful,
+		},
+	}
+
+	if diff := deep.Equal(deployments, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestDeployments_Get(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	deploymentID := "1c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/deployments/%s", groupID, appID, deploymentID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{
+		  "_id": "1c7498dg87d9e6526801572b",
+		  "status": "failed",
+		  "status_error_message": "invalid trigger"
+		}`)
+	})
+
+	deployment, _, err := client.Deployments.Get(ctx, groupID, appID, deploymentID)
+	if err != nil {
+		t.Fatalf("Deployments.Get returned error: %v", err)
+	}
+
+	expected := &Deployment{
+		ID:                 deploymentID,
+		Status:             DeploymentStatusFailed,
+		StatusErrorMessage: "invalid trigger",
+	}
+
+	if diff := deep.Equal(deployment, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestDeployments_Redeploy(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	deploymentID := "1c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/deployments/%s/redeploy", groupID, appID, deploymentID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Deployments.Redeploy(ctx, groupID, appID, deploymentID)
+	if err != nil {
+		t.Fatalf("Deployments.Redeploy returned error: %v", err)
+	}
+}
+
+func TestWaitForDeployment(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	deploymentID := "1c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/deployments/%s", groupID, appID, deploymentID)
+
+	statuses := []string{DeploymentStatusCreated, DeploymentStatusPending, DeploymentStatusSuccessful}
+	polls := 0
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprintf(w, `{"_id": %q, "status": %q}`, deploymentID, statuses[polls])
+		polls++
+	})
+
+	opts := &WaitOptions{MinDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+	deployment, err := WaitForDeployment(ctx, client.Deployments, groupID, appID, deploymentID, opts)
+	if err != nil {
+		t.Fatalf("WaitForDeployment returned error: %v", err)
+	}
+	if deployment.Status != DeploymentStatusSuccessful || polls != len(statuses) {
+		t.Errorf("WaitForDeployment returned status %v after %d polls", deployment.Status, polls)
+	}
+}
+
+func TestWaitForDeployment_failed(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	deploymentID := "1c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/deployments/%s", groupID, appID, deploymentID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"_id": %q, "status": "failed", "status_error_message": "boom"}`, deploymentID)
+	})
+
+	_, err := WaitForDeployment(ctx, client.Deployments, groupID, appID, deploymentID, nil)
+
+	var deploymentErr *DeploymentError
+	if !errors.As(err, &deploymentErr) {
+		t.Fatalf("WaitForDeployment returned %v, expected a *DeploymentError", err)
+	}
+	if deploymentErr.Deployment.StatusErrorMessage != "boom" {
+		t.Errorf("StatusErrorMessage = %v, expected boom", deploymentErr.Deployment.StatusErrorMessage)
+	}
+}
+
+func TestWaitForDeployment_contextCanceled(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	deploymentID := "1c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/deployments/%s", groupID, appID, deploymentID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"_id": %q, "status": "pending"}`, deploymentID)
+	})
+
+	c, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+
+	_, err := WaitForDeployment(c, client.Deployments, groupID, appID, deploymentID, &WaitOptions{MinDelay: time.Hour})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForDeployment returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	atlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	draftsBasePath = appsBasePath + "/%s/drafts"
+)
+
+// DraftsService provides access to the deployment drafts related functions in the Realm API.
+//
+// While a draft exists, changes made through the other services are collected
+// in it instead of being deployed one by one.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/deploy
+type DraftsService interface {
+	Create(context.Context, string, string) (*Draft, *Response, error)
+	Get(context.Context, string, string) (*Draft, *Response, error)
+	Diff(context.Context, string, string, string) (*DraftDiff, *Response, error)
+	Deploy(context.Context, string, string, string) (*Deployment, *Response, error)
+	Delete(context.Context, string, string, string) (*Response, error)
+}
+
+// DraftsServiceOp provides an implementation of the DraftsService interface.
+type DraftsServiceOp service
+
+var _ DraftsService = &DraftsServiceOp{}
+
+// Create creates a new draft. An app can only have one draft at a time.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/deploy
+func (s *DraftsServiceOp) Create(ctx context.Context, groupID, appID string) (*Draft, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	path := fmt.Sprintf(draftsBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Draft)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Get retrieves the current draft of an app. It returns a nil draft if the app has none.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/deploy
+func (s *DraftsServiceOp) Get(ctx context.Context, groupID, appID string) (*Draft, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	path := fmt.Sprintf(draftsBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var root []Draft
+	resp, err := s.Client.Do(ctx, req, &root)
+	if err != nil || len(root) == 0 {
+		return nil, resp, err
+	}
+
+	return &root[0], resp, err
+}
+
+// Diff retrieves the changes collected by a draft.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/deploy
+func (s *DraftsServiceOp) Diff(ctx context.Context, groupID, appID, draftID string) (*DraftDiff, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if draftID == "" {
+		return nil, nil, atlas.NewArgError("draftID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(draftsBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s/diff", basePath, draftID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(DraftDiff)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Deploy deploys a draft. The returned deployment usually has not finished yet.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/deploy
+func (s *DraftsServiceOp) Deploy(ctx context.Context, groupID, appID, draftID string) (*Deployment, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if draftID == "" {
+		return nil, nil, atlas.NewArgError("draftID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(draftsBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s/deployment", basePath, draftID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Deployment)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Delete discards a draft along with every change it collected.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/deploy
+func (s *DraftsServiceOp) Delete(ctx context.Context, groupID, appID, draftID string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if draftID == "" {
+		return nil, atlas.NewArgError("draftID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(draftsBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, draftID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Draft represents a deployment draft.
+type Draft struct {
+	ID     string `json:"_id,omitempty"`
+	UserID string `json:"user_id,omitempty"`
+	AppID  string `json:"app_id,omitempty"`
+}
+
+// DraftDiff represents the changes collected by a draft.
+type DraftDiff struct {
+	Diffs             []string              `json:"diffs,omitempty"`
+	HostingFilesDiff  DraftFilesDiff        `json:"hosting_files_diff"`
+	DependenciesDiff  DraftDependenciesDiff `json:"dependencies_diff"`
+	GraphQLConfigDiff []DraftFieldDiff      `json:"graphql_config_diff,omitempty"`
+	SchemaOptionsDiff []DraftFieldDiff      `json:"schema_options_diff,omitempty"`
+}
+
+// DraftFilesDiff represents the hosted files changed by a draft.
+type DraftFilesDiff struct {
+	Added    []string `json:"added,omitempty"`
+	Deleted  []string `json:"deleted,omitempty"`
+	Modified []string `json:"modified,omitempty"`
+}
+
+// DraftDependenciesDiff represents the dependencies changed by a draft.
+type DraftDependenciesDiff struct {
+	Added    []DraftDependency `json:"added,omitempty"`
+	Deleted  []DraftDependency `json:"deleted,omitempty"`
+	Modified []DraftDependency `json:"modified,omitempty"`
+}
+
+// DraftDependency represents a dependency changed by a draft.
+type DraftDependency struct {
+	Name            string `json:"name,omitempty"`
+	Version         string `json:"version,omitempty"`
+	PreviousVersion string `json:"previous_version,omitempty"`
+}
+
+// DraftFieldDiff represents a configuration field changed by a draft.
+type DraftFieldDiff struct {
+	Previous  interface{} `json:"previous,omitempty"`
+	Updated   interface{} `json:"updated,omitempty"`
+	FieldName string      `json:"field_name,omitempty"`
+}
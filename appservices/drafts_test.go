@@ -0,0 +1,227 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestDrafts_Create(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/drafts", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		fmt.Fprint(w, `{
+		  "_id": "3c7498dg87d9e6526801572b",
+		  "user_id": "2c7498dg87d9e6526801572b",
+		  "app_id": "5c7498dg87d9e6526801572b"
+		}`)
+	})
+
+	draft, _, err := client.Drafts.Create(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("Drafts.Create returned error: %v", err)
+	}
+
+	expected := &Draft{
+		ID:     "3c7498dg87d9e6526801572b",
+		UserID: "2c7498dg87d9e6526801572b",
+		AppID:  appID,
+	}
+
+	if diff := deep.Equal(draft, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestDrafts_Get(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/drafts", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[{
+		  "_id": "3c7498dg87d9e6526801572b",
+		  "user_id": "2c7498dg87d9e6526801572b",
+		  "app_id": "5c7498dg87d9e6526801572b"
+		}]`)
+	})
+
+	draft, _, err := client.Drafts.Get(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("Drafts.Get returned error: %v", err)
+	}
+
+	expected := &Draft{
+		ID:     "3c7498dg87d9e6526801572b",
+		UserID: "2c7498dg87d9e6526801572b",
+		AppID:  appID,
+	}
+
+	if diff := deep.Equal(draft, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestDrafts_Get_none(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/drafts", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[]`)
+	})
+
+	draft, _, err := client.Drafts.Get(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("Drafts.Get returned error: %v", err)
+	}
+	if draft != nil {
+		t.Errorf("Drafts.Get returned %v, expected nil", draft)
+	}
+}
+
+func TestDrafts_Diff(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	draftID := "3c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/drafts/%s/diff", groupID, appID, draftID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{
+		  "diffs": ["Added trigger 'onInsert'"],
+		  "hosting_files_diff": {"added": ["/index.html"], "deleted": [], "modified": []},
+		  "dependencies_diff": {
+		    "added": [{"name": "lodash", "version": "4.17.21"}],
+		    "modified": [{"name": "axios", "version": "1.6.0", "previous_version": "1.5.0"}]
+		  },
+		  "graphql_config_diff": [{"field_name": "use_natural_pluralization", "previous": false, "updated": true}]
+		}`)
+	})
+
+	diff, _, err := client.Drafts.Diff(ctx, groupID, appID, draftID)
+	if err != nil {
+		t.Fatalf("Drafts.Diff returned error: %v", err)
+	}
+
+	expected := &DraftDiff{
+		Diffs: []string{"Added trigger 'onInsert'"},
+		HostingFilesDiff: DraftFilesDiff{
+			Added:    []string{"/index.html"},
+			Deleted:  []string{},
+			Modified: []string{},
+		},
+		DependenciesDiff: DraftDependenciesDiff{
+			Added:    []DraftDependency{{Name: "lodash", Version: "4.17.21"}},
+			Modified: []DraftDependency{{Name: "axios", Version: "1.6.0", PreviousVersion: "1.5.0"}},
+		},
+		GraphQLConfigDiff: []DraftFieldDiff{
+			{FieldName: "use_natural_pluralization", Previous: false, Updated: true},
+		},
+	}
+
+	if d := deep.Equal(diff, expected); d != nil {
+		t.Error(d)
+	}
+}
+
+func TestDrafts_Deploy(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	draftID := "3c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/drafts/%s/deployment", groupID, appID, draftID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		fmt.Fprint(w, `{
+		  "_id": "1c7498dg87d9e6526801572b",
+		  "app_id": "5c7498dg87d9e6526801572b",
+		  "draft_id": "3c7498dg87d9e6526801572b",
+		  "user_id": "2c7498dg87d9e6526801572b",
+		  "deployed_at": 1615840000,
+		  "origin": "UI",
+		  "status": "created"
+		}`)
+	})
+
+	deployment, _, err := client.Drafts.Deploy(ctx, groupID, appID, draftID)
+	if err != nil {
+		t.Fatalf("Drafts.Deploy returned error: %v", err)
+	}
+
+	expected := &Deployment{
+		ID:         "1c7498dg87d9e6526801572b",
+		AppID:      appID,
+		DraftID:    draftID,
+		UserID:     "2c7498dg87d9e6526801572b",
+		DeployedAt: pointer[int64](1615840000),
+		Origin:     "UI",
+		Status:     "created",
+	}
+
+	if diff := deep.Equal(deployment, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestDrafts_Delete(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	draftID := "3c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/drafts/%s", groupID, appID, draftID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Drafts.Delete(ctx, groupID, appID, draftID)
+	if err != nil {
+		t.Fatalf("Drafts.Delete returned error: %v", err)
+	}
+}
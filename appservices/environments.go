@@ -0,0 +1,257 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	atlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	environmentValuesBasePath = appsBasePath + "/%s/environment_values"
+	environmentBasePath       = appsBasePath + "/%s/environment"
+)
+
+// Environments an app can be assigned to. EnvironmentNone means the app has no environment.
+const (
+	EnvironmentNone        = ""
+	EnvironmentDevelopment = "development"
+	EnvironmentTesting     = "testing"
+	EnvironmentQA          = "qa"
+	EnvironmentProduction  = "production"
+)
+
+// EnvironmentsService provides access to the environments related functions in the Realm API.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/environments
+type EnvironmentsService interface {
+	List(context.Context, string, string) ([]EnvironmentValue, *Response, error)
+	Get(context.Context, string, string, string) (*EnvironmentValue, *Response, error)
+	Create(context.Context, string, string, *EnvironmentValue) (*EnvironmentValue, *Response, error)
+	Update(context.Context, string, string, string, *EnvironmentValue) (*Response, error)
+	Delete(context.Context, string, string, string) (*Response, error)
+	GetCurrent(context.Context, string, string) (string, *Response, error)
+	SetCurrent(context.Context, string, string, string) (*Response, error)
+}
+
+// EnvironmentsServiceOp provides an implementation of the EnvironmentsService interface.
+type EnvironmentsServiceOp service
+
+var _ EnvironmentsService = &EnvironmentsServiceOp{}
+
+// List all environment values.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/environments
+func (s *EnvironmentsServiceOp) List(ctx context.Context, groupID, appID string) ([]EnvironmentValue, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	path := fmt.Sprintf(environmentValuesBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var root []EnvironmentValue
+	resp, err := s.Client.Do(ctx, req, &root)
+
+	return root, resp, err
+}
+
+// Get retrieves a single environment value, including its content for every environment.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/environments
+func (s *EnvironmentsServiceOp) Get(ctx context.Context, groupID, appID, environmentValueID string) (*EnvironmentValue, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if environmentValueID == "" {
+		return nil, nil, atlas.NewArgError("environmentValueID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(environmentValuesBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, environmentValueID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(EnvironmentValue)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Create creates an environment value.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/environments
+func (s *EnvironmentsServiceOp) Create(ctx context.Context, groupID, appID string, createRequest *EnvironmentValue) (*EnvironmentValue, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if createRequest == nil {
+		return nil, nil, atlas.NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf(environmentValuesBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(EnvironmentValue)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Update replaces an environment value.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/environments
+func (s *EnvironmentsServiceOp) Update(ctx context.Context, groupID, appID, environmentValueID string, updateRequest *EnvironmentValue) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if environmentValueID == "" {
+		return nil, atlas.NewArgError("environmentValueID", "must be set")
+	}
+	if updateRequest == nil {
+		return nil, atlas.NewArgError("updateRequest", "cannot be nil")
+	}
+
+	basePath := fmt.Sprintf(environmentValuesBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, environmentValueID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, path, updateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Delete deletes an environment value.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/environments
+func (s *EnvironmentsServiceOp) Delete(ctx context.Context, groupID, appID, environmentValueID string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if environmentValueID == "" {
+		return nil, atlas.NewArgError("environmentValueID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(environmentValuesBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, environmentValueID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// GetCurrent retrieves the environment the app is currently assigned to.
+// It returns EnvironmentNone if the app has no environment.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/environments
+func (s *EnvironmentsServiceOp) GetCurrent(ctx context.Context, groupID, appID string) (string, *Response, error) {
+	if groupID == "" {
+		return "", nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return "", nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	path := fmt.Sprintf(environmentBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	root := new(appEnvironment)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return "", resp, err
+	}
+
+	return root.Environment, resp, err
+}
+
+// SetCurrent assigns the app to an environment. Passing EnvironmentNone removes the app from its environment.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/environments
+func (s *EnvironmentsServiceOp) SetCurrent(ctx context.Context, groupID, appID, environment string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	path := fmt.Sprintf(environmentBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, path, &appEnvironment{Environment: environment})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// EnvironmentValue represents an App Services environment value. Values holds the
+// content of the value for each environment, keyed by environment name; the
+// EnvironmentNone key holds the content used when the app has no environment.
+type EnvironmentValue struct {
+	Values       map[string]interface{} `json:"values,omitempty"`
+	LastModified *int64                 `json:"last_modified,omitempty"`
+	ID           string                 `json:"_id,omitempty"`
+	Name         string                 `json:"name,omitempty"`
+}
+
+// appEnvironment is the request and response body of the app environment endpoint.
+type appEnvironment struct {
+	Environment string `json:"environment"`
+}
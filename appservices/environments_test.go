@@ -0,0 +1,261 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestEnvironments_List(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/environment_values", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[{"_id": "1", "name": "tier", "last_modified": 1615840000}]`)
+	})
+
+	values, _, err := client.Environments.List(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("Environments.List returned error: %v", err)
+	}
+
+	expected := []EnvironmentValue{
+		{ID: "1", Name: "tier", LastModified: pointer[int64](1615840000)},
+	}
+
+	if diff := deep.Equal(values, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestEnvironments_Get(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	valueID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/environment_values/%s", groupID, appID, valueID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{
+		  "_id": "1",
+		  "name": "tier",
+		  "values": {"": "none", "development": "dev", "production": {"replicas": 3}}
+		}`)
+	})
+
+	value, _, err := client.Environments.Get(ctx, groupID, appID, valueID)
+	if err != nil {
+		t.Fatalf("Environments.Get returned error: %v", err)
+	}
+
+	expected := &EnvironmentValue{
+		ID:   "1",
+		Name: "tier",
+		Values: map[string]interface{}{
+			EnvironmentNone:        "none",
+			EnvironmentDevelopment: "dev",
+			EnvironmentProduction:  map[string]interface{}{"replicas": float64(3)},
+		},
+	}
+
+	if diff := deep.Equal(value, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestEnvironments_Create(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/environment_values", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		expected := map[string]interface{}{
+			"name":   "tier",
+			"values": map[string]interface{}{"testing": "test", "qa": "qa"},
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		fmt.Fprint(w, `{"_id": "1", "name": "tier", "values": {"testing": "test", "qa": "qa"}}`)
+	})
+
+	createRequest := &EnvironmentValue{
+		Name:   "tier",
+		Values: map[string]interface{}{EnvironmentTesting: "test", EnvironmentQA: "qa"},
+	}
+
+	value, _, err := client.Environments.Create(ctx, groupID, appID, createRequest)
+	if err != nil {
+		t.Fatalf("Environments.Create returned error: %v", err)
+	}
+
+	expected := &EnvironmentValue{
+		ID:     "1",
+		Name:   "tier",
+		Values: map[string]interface{}{EnvironmentTesting: "test", EnvironmentQA: "qa"},
+	}
+
+	if diff := deep.Equal(value, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestEnvironments_Update(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	valueID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/environment_values/%s", groupID, appID, valueID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		expected := map[string]interface{}{
+			"_id":    "1",
+			"name":   "tier",
+			"values": map[string]interface{}{"production": "prod"},
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	updateRequest := &EnvironmentValue{
+		ID:     "1",
+		Name:   "tier",
+		Values: map[string]interface{}{EnvironmentProduction: "prod"},
+	}
+
+	_, err := client.Environments.Update(ctx, groupID, appID, valueID, updateRequest)
+	if err != nil {
+		t.Fatalf("Environments.Update returned error: %v", err)
+	}
+}
+
+func TestEnvironments_Delete(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	valueID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/environment_values/%s", groupID, appID, valueID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Environments.Delete(ctx, groupID, appID, valueID)
+	if err != nil {
+		t.Fatalf("Environments.Delete returned error: %v", err)
+	}
+}
+
+func TestEnvironments_GetCurrent(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/environment", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"environment": "qa"}`)
+	})
+
+	environment, _, err := client.Environments.GetCurrent(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("Environments.GetCurrent returned error: %v", err)
+	}
+
+	if environment != EnvironmentQA {
+		t.Errorf("expected environment %q, got %q", EnvironmentQA, environment)
+	}
+}
+
+func TestEnvironments_SetCurrent(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/environment", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		expected := map[string]interface{}{"environment": ""}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Environments.SetCurrent(ctx, groupID, appID, EnvironmentNone)
+	if err != nil {
+		t.Fatalf("Environments.SetCurrent returned error: %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	atlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	debugBasePath = appsBasePath + "/%s/debug"
+)
+
+// Execute calls a deployed function by name. If result is non-nil, the value
+// returned by the function is JSON decoded into it.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/functions
+func (s *FunctionsServiceOp) Execute(ctx context.Context, groupID, appID string, executeRequest *ExecuteFunctionRequest, opts *ExecuteOptions, result interface{}) (*ExecutionResult, *Response, error) {
+	if executeRequest == nil {
+		return nil, nil, atlas.NewArgError("executeRequest", "cannot be nil")
+	}
+	if executeRequest.Name == "" {
+		return nil, nil, atlas.NewArgError("executeRequest.Name", "must be set")
+	}
+
+	return s.execute(ctx, groupID, appID, "execute_function", executeRequest, opts, result)
+}
+
+// ExecuteSource runs ad-hoc function source. If result is non-nil, the value
+// returned by the source is JSON decoded into it.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/functions
+func (s *FunctionsServiceOp) ExecuteSource(ctx context.Context, groupID, appID string, executeRequest *ExecuteSourceRequest, opts *ExecuteOptions, result interface{}) (*ExecutionResult, *Response, error) {
+	if executeRequest == nil {
+		return nil, nil, atlas.NewArgError("executeRequest", "cannot be nil")
+	}
+	if executeRequest.Source == "" {
+		return nil, nil, atlas.NewArgError("executeRequest.Source", "must be set")
+	}
+
+	return s.execute(ctx, groupID, appID, "execute_function_source", executeRequest, opts, result)
+}
+
+func (s *FunctionsServiceOp) execute(ctx context.Context, groupID, appID, endpoint string, body interface{}, opts *ExecuteOptions, result interface{}) (*ExecutionResult, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(debugBasePath, groupID, appID)
+	path, err := setQueryParams(fmt.Sprintf("%s/%s", basePath, endpoint), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(ExecutionResult)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if result != nil && len(root.Result) > 0 {
+		if err := json.Unmarshal(root.Result, result); err != nil {
+			return root, resp, err
+		}
+	}
+
+	return root, resp, err
+}
+
+// ExecuteOptions specifies the user a function runs as. When neither is set the
+// function runs as the user that authenticated the client.
+type ExecuteOptions struct {
+	UserID      string `url:"user_id,omitempty"`
+	RunAsSystem bool   `url:"run_as_system,omitempty"`
+}
+
+// ExecuteFunctionRequest represents a request to call a function by name.
+type ExecuteFunctionRequest struct {
+	Name      string        `json:"name"`
+	Arguments []interface{} `json:"arguments"`
+}
+
+// ExecuteSourceRequest represents a request to run ad-hoc function source.
+type ExecuteSourceRequest struct {
+	Source string `json:"source"`
+	// EvalSource is evaluated before Source, typically to define a context for it.
+	EvalSource string `json:"eval_source,omitempty"`
+}
+
+// ExecutionResult represents the outcome of a function execution.
+type ExecutionResult struct {
+	// Result is the value returned by the function, in Extended JSON.
+	Result    json.RawMessage `json:"result,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	Logs      []string        `json:"logs,omitempty"`
+	ErrorLogs []string        `json:"error_logs,omitempty"`
+	Stats     ExecutionStats  `json:"stats"`
+}
+
+// ExecutionStats represents the statistics of a function execution.
+type ExecutionStats struct {
+	ExecutionTime string `json:"execution_time,omitempty"`
+}
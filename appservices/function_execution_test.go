@@ -0,0 +1,147 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestFunctions_Execute(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/debug/execute_function", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		if got := r.URL.Query().Get("user_id"); got != "1c7498dg87d9e6526801572b" {
+			t.Errorf("user_id = %v, expected %v", got, "1c7498dg87d9e6526801572b")
+		}
+
+		expected := map[string]interface{}{
+			"name":      "sum",
+			"arguments": []interface{}{1.0, 2.0},
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		fmt.Fprint(w, `{
+		  "result": {"total": 3},
+		  "logs": ["adding"],
+		  "error_logs": null,
+		  "stats": {"execution_time": "1.2ms"}
+		}`)
+	})
+
+	var result struct {
+		Total int `json:"total"`
+	}
+	res, _, err := client.Functions.Execute(ctx, groupID, appID,
+		&ExecuteFunctionRequest{Name: "sum", Arguments: []interface{}{1, 2}},
+		&ExecuteOptions{UserID: "1c7498dg87d9e6526801572b"},
+		&result)
+	if err != nil {
+		t.Fatalf("Functions.Execute returned error: %v", err)
+	}
+
+	if result.Total != 3 {
+		t.Errorf("result.Total = %v, expected 3", result.Total)
+	}
+
+	expected := &ExecutionResult{
+		Result: json.RawMessage(`{"total": 3}`),
+		Logs:   []string{"adding"},
+		Stats:  ExecutionStats{ExecutionTime: "1.2ms"},
+	}
+
+	if diff := deep.Equal(res, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestFunctions_ExecuteSource(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/debug/execute_function_source", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		if got := r.URL.Query().Get("run_as_system"); got != "true" {
+			t.Errorf("run_as_system = %v, expected true", got)
+		}
+
+		expected := map[string]interface{}{
+			"source":      "exports = function() { throw new Error('boom'); };",
+			"eval_source": "exports()",
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		fmt.Fprint(w, `{
+		  "error": "boom",
+		  "error_logs": ["Error: boom"],
+		  "stats": {"execution_time": "0.5ms"}
+		}`)
+	})
+
+	res, _, err := client.Functions.ExecuteSource(ctx, groupID, appID,
+		&ExecuteSourceRequest{
+			Source:     "exports = function() { throw new Error('boom'); };",
+			EvalSource: "exports()",
+		},
+		&ExecuteOptions{RunAsSystem: true},
+		nil)
+	if err != nil {
+		t.Fatalf("Functions.ExecuteSource returned error: %v", err)
+	}
+
+	expected := &ExecutionResult{
+		Error:     "boom",
+		ErrorLogs: []string{"Error: boom"},
+		Stats:     ExecutionStats{ExecutionTime: "0.5ms"},
+	}
+
+	if diff := deep.Equal(res, expected); diff != nil {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	atlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	functionsBasePath = appsBasePath + "/%s/functions"
+)
+
+// FunctionsService provides access to the functions related functions in the Realm API.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/functions
+type FunctionsService interface {
+	List(context.Context, string, string) ([]Function, *Response, error)
+	Get(context.Context, string, string, string) (*Function, *Response, error)
+	Create(context.Context, string, string, *Function) (*Function, *Response, error)
+	Update(context.Context, string, string, string, *Function) (*Response, error)
+	Delete(context.Context, string, string, string) (*Response, error)
+	Execute(context.Context, string, string, *ExecuteFunctionRequest, *ExecuteOptions, interface{}) (*ExecutionResult, *Response, error)
+	ExecuteSource(context.Context, string, string, *ExecuteSourceRequest, *ExecuteOptions, interface{}) (*ExecutionResult, *Response, error)
+}
+
+// FunctionsServiceOp provides an implementation of the FunctionsService interface.
+type FunctionsServiceOp service
+
+var _ FunctionsService = &FunctionsServiceOp{}
+
+// List all functions. Only the ID, name and last modification date of each function are returned.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/functions
+func (s *FunctionsServiceOp) List(ctx context.Context, groupID, appID string) ([]Function, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	path := fmt.Sprintf(functionsBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var root []Function
+	resp, err := s.Client.Do(ctx, req, &root)
+
+	return root, resp, err
+}
+
+// Get retrieves a single function, including its source.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/functions
+func (s *FunctionsServiceOp) Get(ctx context.Context, groupID, appID, functionID string) (*Function, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if functionID == "" {
+		return nil, nil, atlas.NewArgError("functionID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(functionsBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, functionID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Function)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Create creates a function.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/functions
+func (s *FunctionsServiceOp) Create(ctx context.Context, groupID, appID string, createRequest *Function) (*Function, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if createRequest == nil {
+		return nil, nil, atlas.NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf(functionsBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Function)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Update replaces a function.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/functions
+func (s *FunctionsServiceOp) Update(ctx context.Context, groupID, appID, functionID string, updateRequest *Function) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if functionID == "" {
+		return nil, atlas.NewArgError("functionID", "must be set")
+	}
+	if updateRequest == nil {
+		return nil, atlas.NewArgError("updateRequest", "cannot be nil")
+	}
+
+	basePath := fmt.Sprintf(functionsBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, functionID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, path, updateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Delete deletes a function.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/functions
+func (s *FunctionsServiceOp) Delete(ctx context.Context, groupID, appID, functionID string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if functionID == "" {
+		return nil, atlas.NewArgError("functionID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(functionsBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, functionID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Function represents an App Services function.
+type Function struct {
+	// CanEvaluate is a rule expression that must evaluate to true for the function to run.
+	CanEvaluate    interface{} `json:"can_evaluate,omitempty"`
+	Private        *bool       `json:"private,omitempty"`
+	RunAsSystem    *bool       `json:"run_as_system,omitempty"`
+	DisableArgLogs *bool       `json:"disable_arg_logs,omitempty"`
+	LastModified   *int64      `json:"last_modified,omitempty"`
+	ID             string      `json:"_id,omitempty"`
+	Name           string      `json:"name,omitempty"`
+	Source         string      `json:"source,omitempty"`
+	RunAsUserID    string      `json:"run_as_user_id,omitempty"`
+}
@@ -0,0 +1,215 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestFunctions_List(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/functions", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[{
+		  "_id": "4c7498dg87d9e6526801572b",
+		  "name": "handleInsert",
+		  "last_modified": 1615840000
+		}]`)
+	})
+
+	functions, _, err := client.Functions.List(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("Functions.List returned error: %v", err)
+	}
+
+	expected := []Function{
+		{
+			ID:           "4c7498dg87d9e6526801572b",
+			Name:         "handleInsert",
+			LastModified: pointer[int64](1615840000),
+		},
+	}
+
+	if diff := deep.Equal(functions, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestFunctions_Get(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	functionID := "4c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/functions/%s", groupID, appID, functionID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		_, _ = w.Write([]byte(`{
+		  "_id": "4c7498dg87d9e6526801572b",
+		  "name": "handleInsert",
+		  "source": "exports = function(event) {};",
+		  "private": true,
+		  "run_as_system": true,
+		  "can_evaluate": {"%%true": true},
+		  "disable_arg_logs": false
+		}`))
+	})
+
+	function, _, err := client.Functions.Get(ctx, groupID, appID, functionID)
+	if err != nil {
+		t.Fatalf("Functions.Get returned error: %v", err)
+	}
+
+	expected := &Function{
+		ID:             functionID,
+		Name:           "handleInsert",
+		Source:         "exports = function(event) {};",
+		Private:        pointer(true),
+		RunAsSystem:    pointer(true),
+		CanEvaluate:    map[string]interface{}{"%%true": true},
+		DisableArgLogs: pointer(false),
+	}
+
+	if diff := deep.Equal(function, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestFunctions_Create(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	createRequest := &Function{
+		Name:        "handleInsert",
+		Source:      "exports = function(event) {};",
+		Private:     pointer(false),
+		RunAsSystem: pointer(true),
+	}
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/functions", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		expected := map[string]interface{}{
+			"name":          "handleInsert",
+			"source":        "exports = function(event) {};",
+			"private":       false,
+			"run_as_system": true,
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		fmt.Fprint(w, `{"_id": "4c7498dg87d9e6526801572b", "name": "handleInsert"}`)
+	})
+
+	function, _, err := client.Functions.Create(ctx, groupID, appID, createRequest)
+	if err != nil {
+		t.Fatalf("Functions.Create returned error: %v", err)
+	}
+
+	expected := &Function{
+		ID:   "4c7498dg87d9e6526801572b",
+		Name: "handleInsert",
+	}
+
+	if diff := deep.Equal(function, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestFunctions_Update(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	functionID := "4c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/functions/%s", groupID, appID, functionID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		expected := map[string]interface{}{
+			"name":   "handleInsert",
+			"source": "exports = function(event) { return 1; };",
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Functions.Update(ctx, groupID, appID, functionID, &Function{
+		Name:   "handleInsert",
+		Source: "exports = function(event) { return 1; };",
+	})
+	if err != nil {
+		t.Fatalf("Functions.Update returned error: %v", err)
+	}
+}
+
+func TestFunctions_Delete(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	functionID := "4c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/functions/%s", groupID, appID, functionID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Functions.Delete(ctx, groupID, appID, functionID)
+	if err != nil {
+		t.Fatalf("Functions.Delete returned error: %v", err)
+	}
+}
@@ -0,0 +1,406 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	atlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	rulesBasePath       = servicesBasePath + "/%s/rules"
+	defaultRuleBasePath = servicesBasePath + "/%s/default_rule"
+)
+
+// RulesService provides access to the data access rules of a data source in the Realm API.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/rules
+type RulesService interface {
+	List(context.Context, string, string, string) ([]Rule, *Response, error)
+	Get(context.Context, string, string, string, string) (*Rule, *Response, error)
+	GetByNamespace(context.Context, string, string, string, string, string) (*Rule, *Response, error)
+	Create(context.Context, string, string, string, *Rule) (*Rule, *Response, error)
+	Update(context.Context, string, string, string, string, *Rule) (*Response, error)
+	Delete(context.Context, string, string, string, string) (*Response, error)
+	GetDefault(context.Context, string, string, string) (*DefaultRule, *Response, error)
+	CreateDefault(context.Context, string, string, string, *DefaultRule) (*DefaultRule, *Response, error)
+	UpdateDefault(context.Context, string, string, string, string, *DefaultRule) (*Response, error)
+	DeleteDefault(context.Context, string, string, string, string) (*Response, error)
+}
+
+// RulesServiceOp provides an implementation of the RulesService interface.
+type RulesServiceOp service
+
+var _ RulesService = &RulesServiceOp{}
+
+// List all collection rules of a data source. Only the ID and namespace of each rule are returned.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/rules
+func (s *RulesServiceOp) List(ctx context.Context, groupID, appID, serviceID string) ([]Rule, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if serviceID == "" {
+		return nil, nil, atlas.NewArgError("serviceID", "must be set")
+	}
+
+	path := fmt.Sprintf(rulesBasePath, groupID, appID, serviceID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var root []Rule
+	resp, err := s.Client.Do(ctx, req, &root)
+
+	return root, resp, err
+}
+
+// Get retrieves a single collection rule, including its roles and filters.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/rules
+func (s *RulesServiceOp) Get(ctx context.Context, groupID, appID, serviceID, ruleID string) (*Rule, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if serviceID == "" {
+		return nil, nil, atlas.NewArgError("serviceID", "must be set")
+	}
+	if ruleID == "" {
+		return nil, nil, atlas.NewArgError("ruleID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(rulesBasePath, groupID, appID, serviceID)
+	path := fmt.Sprintf("%s/%s", basePath, ruleID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Rule)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// GetByNamespace retrieves the collection rule of the database.collection namespace.
+// It returns a nil rule if the namespace has no rule of its own, in which case
+// the default rule of the data source applies.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/rules
+func (s *RulesServiceOp) GetByNamespace(ctx context.Context, groupID, appID, serviceID, database, collection string) (*Rule, *Response, error) {
+	if database == "" {
+		return nil, nil, atlas.NewArgError("database", "must be set")
+	}
+	if collection == "" {
+		return nil, nil, atlas.NewArgError("collection", "must be set")
+	}
+
+	rules, resp, err := s.List(ctx, groupID, appID, serviceID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	for i := range rules {
+		if rules[i].Database == database && rules[i].Collection == collection {
+			return s.Get(ctx, groupID, appID, serviceID, rules[i].ID)
+		}
+	}
+
+	return nil, resp, nil
+}
+
+// Create creates a collection rule.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/rules
+func (s *RulesServiceOp) Create(ctx context.Context, groupID, appID, serviceID string, createRequest *Rule) (*Rule, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if serviceID == "" {
+		return nil, nil, atlas.NewArgError("serviceID", "must be set")
+	}
+	if createRequest == nil {
+		return nil, nil, atlas.NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf(rulesBasePath, groupID, appID, serviceID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Rule)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Update replaces a collection rule.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/rules
+func (s *RulesServiceOp) Update(ctx context.Context, groupID, appID, serviceID, ruleID string, updateRequest *Rule) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if serviceID == "" {
+		return nil, atlas.NewArgError("serviceID", "must be set")
+	}
+	if ruleID == "" {
+		return nil, atlas.NewArgError("ruleID", "must be set")
+	}
+	if updateRequest == nil {
+		return nil, atlas.NewArgError("updateRequest", "cannot be nil")
+	}
+
+	basePath := fmt.Sprintf(rulesBasePath, groupID, appID, serviceID)
+	path := fmt.Sprintf("%s/%s", basePath, ruleID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, path, updateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Delete deletes a collection rule.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/rules
+func (s *RulesServiceOp) Delete(ctx context.Context, groupID, appID, serviceID, ruleID string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if serviceID == "" {
+		return nil, atlas.NewArgError("serviceID", "must be set")
+	}
+	if ruleID == "" {
+		return nil, atlas.NewArgError("ruleID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(rulesBasePath, groupID, appID, serviceID)
+	path := fmt.Sprintf("%s/%s", basePath, ruleID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// GetDefault retrieves the default rule of a data source.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/rules
+func (s *RulesServiceOp) GetDefault(ctx context.Context, groupID, appID, serviceID string) (*DefaultRule, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if serviceID == "" {
+		return nil, nil, atlas.NewArgError("serviceID", "must be set")
+	}
+
+	path := fmt.Sprintf(defaultRuleBasePath, groupID, appID, serviceID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(DefaultRule)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// CreateDefault creates the default rule of a data source.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/rules
+func (s *RulesServiceOp) CreateDefault(ctx context.Context, groupID, appID, serviceID string, createRequest *DefaultRule) (*DefaultRule, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if serviceID == "" {
+		return nil, nil, atlas.NewArgError("serviceID", "must be set")
+	}
+	if createRequest == nil {
+		return nil, nil, atlas.NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf(defaultRuleBasePath, groupID, appID, serviceID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(DefaultRule)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// UpdateDefault replaces the default rule of a data source.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/rules
+func (s *RulesServiceOp) UpdateDefault(ctx context.Context, groupID, appID, serviceID, ruleID string, updateRequest *DefaultRule) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if serviceID == "" {
+		return nil, atlas.NewArgError("serviceID", "must be set")
+	}
+	if ruleID == "" {
+		return nil, atlas.NewArgError("ruleID", "must be set")
+	}
+	if updateRequest == nil {
+		return nil, atlas.NewArgError("updateRequest", "cannot be nil")
+	}
+
+	basePath := fmt.Sprintf(defaultRuleBasePath, groupID, appID, serviceID)
+	path := fmt.Sprintf("%s/%s", basePath, ruleID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, path, updateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// DeleteDefault deletes the default rule of a data source.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/rules
+func (s *RulesServiceOp) DeleteDefault(ctx context.Context, groupID, appID, serviceID, ruleID string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if serviceID == "" {
+		return nil, atlas.NewArgError("serviceID", "must be set")
+	}
+	if ruleID == "" {
+		return nil, atlas.NewArgError("ruleID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(defaultRuleBasePath, groupID, appID, serviceID)
+	path := fmt.Sprintf("%s/%s", basePath, ruleID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Rule represents the data access rule of a single collection.
+type Rule struct {
+	ID         string   `json:"_id,omitempty"`
+	Database   string   `json:"database,omitempty"`
+	Collection string   `json:"collection,omitempty"`
+	Roles      []Role   `json:"roles,omitempty"`
+	Filters    []Filter `json:"filters,omitempty"`
+}
+
+// DefaultRule represents the rule applied to the collections of a data source without a rule of their own.
+type DefaultRule struct {
+	ID      string   `json:"_id,omitempty"`
+	Roles   []Role   `json:"roles,omitempty"`
+	Filters []Filter `json:"filters,omitempty"`
+}
+
+// Role represents a set of permissions granted to the users matching ApplyWhen.
+// Roles are evaluated in order and the first matching role applies.
+type Role struct {
+	// ApplyWhen is the expression a user must match for the role to apply.
+	ApplyWhen        map[string]interface{}      `json:"apply_when,omitempty"`
+	DocumentFilters  *DocumentFilters            `json:"document_filters,omitempty"`
+	Fields           map[string]FieldPermissions `json:"fields,omitempty"`
+	AdditionalFields *FieldPermissions           `json:"additional_fields,omitempty"`
+	Read             *bool                       `json:"read,omitempty"`
+	Write            *bool                       `json:"write,omitempty"`
+	Insert           *bool                       `json:"insert,omitempty"`
+	Delete           *bool                       `json:"delete,omitempty"`
+	Search           *bool                       `json:"search,omitempty"`
+	Name             string                      `json:"name"`
+}
+
+// DocumentFilters restricts the documents a role can read or write. Each
+// filter is an expression document, or a boolean.
+type DocumentFilters struct {
+	Read  interface{} `json:"read,omitempty"`
+	Write interface{} `json:"write,omitempty"`
+}
+
+// FieldPermissions represents the read and write permissions of a field. Fields
+// holds the permissions of the sub-fields of an embedded document.
+type FieldPermissions struct {
+	Read   *bool                       `json:"read,omitempty"`
+	Write  *bool                       `json:"write,omitempty"`
+	Fields map[string]FieldPermissions `json:"fields,omitempty"`
+}
+
+// Filter represents a query filter applied to every operation of the users matching ApplyWhen.
+type Filter struct {
+	ApplyWhen  map[string]interface{} `json:"apply_when,omitempty"`
+	Query      map[string]interface{} `json:"query,omitempty"`
+	Projection map[string]int         `json:"projection,omitempty"`
+	Name       string                 `json:"name"`
+}
@@ -0,0 +1,343 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestRules_List(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	serviceID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/services/%s/rules", groupID, appID, serviceID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[{"_id": "2", "database": "store", "collection": "orders"}]`)
+	})
+
+	rules, _, err := client.Rules.List(ctx, groupID, appID, serviceID)
+	if err != nil {
+		t.Fatalf("Rules.List returned error: %v", err)
+	}
+
+	expected := []Rule{{ID: "2", Database: "store", Collection: "orders"}}
+
+	if diff := deep.Equal(rules, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestRules_Get(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	serviceID := "1"
+	ruleID := "2"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/services/%s/rules/%s", groupID, appID, serviceID, ruleID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		_, _ = w.Write([]byte(`{
+		  "_id": "2",
+		  "database": "store",
+		  "collection": "orders",
+		  "roles": [{
+		    "name": "owner",
+		    "apply_when": {"owner_id": "%user.id"},
+		    "document_filters": {"read": {"owner_id": "%user.id"}, "write": true},
+		    "fields": {"address": {"read": true, "fields": {"zip": {"write": false}}}},
+		    "additional_fields": {"read": true, "write": false},
+		    "read": true,
+		    "write": true,
+		    "insert": true,
+		    "delete": false,
+		    "search": true
+		  }],
+		  "filters": [{"name": "active", "apply_when": {}, "query": {"active": true}, "projection": {"secret": 0}}]
+		}`))
+	})
+
+	rule, _, err := client.Rules.Get(ctx, groupID, appID, serviceID, ruleID)
+	if err != nil {
+		t.Fatalf("Rules.Get returned error: %v", err)
+	}
+
+	expected := &Rule{
+		ID:         "2",
+		Database:   "store",
+		Collection: "orders",
+		Roles: []Role{{
+			Name:      "owner",
+			ApplyWhen: map[string]interface{}{"owner_id": "%user.id"},
+			DocumentFilters: &DocumentFilters{
+				Read:  map[string]interface{}{"owner_id": "%user.id"},
+				Write: true,
+			},
+			Fields: map[string]FieldPermissions{
+				"address": {
+					Read:   pointer(true),
+					Fields: map[string]FieldPermissions{"zip": {Write: pointer(false)}},
+				},
+			},
+			AdditionalFields: &FieldPermissions{Read: pointer(true), Write: pointer(false)},
+			Read:             pointer(true),
+			Write:            pointer(true),
+			Insert:           pointer(true),
+			Delete:           pointer(false),
+			Search:           pointer(true),
+		}},
+		Filters: []Filter{{
+			Name:       "active",
+			ApplyWhen:  map[string]interface{}{},
+			Query:      map[string]interface{}{"active": true},
+			Projection: map[string]int{"secret": 0},
+		}},
+	}
+
+	if diff := deep.Equal(rule, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestRules_GetByNamespace(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	serviceID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/services/%s/rules", groupID, appID, serviceID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[
+		  {"_id": "2", "database": "store", "collection": "orders"},
+		  {"_id": "3", "database": "store", "collection": "customers"}
+		]`)
+	})
+	mux.HandleFunc(path+"/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"_id": "3", "database": "store", "collection": "customers", "roles": [{"name": "readAll", "read": true}]}`)
+	})
+
+	rule, _, err := client.Rules.GetByNamespace(ctx, groupID, appID, serviceID, "store", "customers")
+	if err != nil {
+		t.Fatalf("Rules.GetByNamespace returned error: %v", err)
+	}
+
+	expected := &Rule{
+		ID:         "3",
+		Database:   "store",
+		Collection: "customers",
+		Roles:      []Role{{Name: "readAll", Read: pointer(true)}},
+	}
+
+	if diff := deep.Equal(rule, expected); diff != nil {
+		t.Error(diff)
+	}
+
+	rule, _, err = client.Rules.GetByNamespace(ctx, groupID, appID, serviceID, "store", "products")
+	if err != nil {
+		t.Fatalf("Rules.GetByNamespace returned error: %v", err)
+	}
+	if rule != nil {
+		t.Errorf("expected no rule, got %+v", rule)
+	}
+}
+
+func TestRules_Create(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	serviceID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/services/%s/rules", groupID, appID, serviceID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		expected := map[string]interface{}{
+			"database":   "store",
+			"collection": "orders",
+			"roles": []interface{}{
+				map[string]interface{}{
+					"name":   "readOnly",
+					"read":   true,
+					"write":  false,
+					"fields": map[string]interface{}{"total": map[string]interface{}{"read": true}},
+				},
+			},
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		fmt.Fprint(w, `{"_id": "2", "database": "store", "collection": "orders"}`)
+	})
+
+	createRequest := &Rule{
+		Database:   "store",
+		Collection: "orders",
+		Roles: []Role{{
+			Name:   "readOnly",
+			Read:   pointer(true),
+			Write:  pointer(false),
+			Fields: map[string]FieldPermissions{"total": {Read: pointer(true)}},
+		}},
+	}
+
+	rule, _, err := client.Rules.Create(ctx, groupID, appID, serviceID, createRequest)
+	if err != nil {
+		t.Fatalf("Rules.Create returned error: %v", err)
+	}
+
+	expected := &Rule{ID: "2", Database: "store", Collection: "orders"}
+
+	if diff := deep.Equal(rule, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestRules_Update(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	serviceID := "1"
+	ruleID := "2"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/services/%s/rules/%s", groupID, appID, serviceID, ruleID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Rules.Update(ctx, groupID, appID, serviceID, ruleID, &Rule{ID: ruleID})
+	if err != nil {
+		t.Fatalf("Rules.Update returned error: %v", err)
+	}
+}
+
+func TestRules_Delete(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	serviceID := "1"
+	ruleID := "2"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/services/%s/rules/%s", groupID, appID, serviceID, ruleID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Rules.Delete(ctx, groupID, appID, serviceID, ruleID)
+	if err != nil {
+		t.Fatalf("Rules.Delete returned error: %v", err)
+	}
+}
+
+func TestRules_DefaultRule(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	serviceID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/services/%s/default_rule", groupID, appID, serviceID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"_id": "4", "roles": [{"name": "readAll", "read": true}]}`)
+		case http.MethodPost:
+			var v map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+				t.Fatalf("Decode json: %v", err)
+			}
+			expected := map[string]interface{}{
+				"roles": []interface{}{map[string]interface{}{"name": "denyAll", "read": false, "write": false}},
+			}
+			if diff := deep.Equal(v, expected); diff != nil {
+				t.Error(diff)
+			}
+			fmt.Fprint(w, `{"_id": "4"}`)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+	mux.HandleFunc(path+"/4", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut && r.Method != http.MethodDelete {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	rule, _, err := client.Rules.GetDefault(ctx, groupID, appID, serviceID)
+	if err != nil {
+		t.Fatalf("Rules.GetDefault returned error: %v", err)
+	}
+
+	expected := &DefaultRule{ID: "4", Roles: []Role{{Name: "readAll", Read: pointer(true)}}}
+
+	if diff := deep.Equal(rule, expected); diff != nil {
+		t.Error(diff)
+	}
+
+	denyAll := &DefaultRule{Roles: []Role{{Name: "denyAll", Read: pointer(false), Write: pointer(false)}}}
+	rule, _, err = client.Rules.CreateDefault(ctx, groupID, appID, serviceID, denyAll)
+	if err != nil {
+		t.Fatalf("Rules.CreateDefault returned error: %v", err)
+	}
+	if rule.ID != "4" {
+		t.Errorf("expected default rule ID 4, got %q", rule.ID)
+	}
+
+	if _, err := client.Rules.UpdateDefault(ctx, groupID, appID, serviceID, "4", denyAll); err != nil {
+		t.Fatalf("Rules.UpdateDefault returned error: %v", err)
+	}
+	if _, err := client.Rules.DeleteDefault(ctx, groupID, appID, serviceID, "4"); err != nil {
+		t.Fatalf("Rules.DeleteDefault returned error: %v", err)
+	}
+}
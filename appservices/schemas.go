@@ -0,0 +1,362 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	atlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	schemasBasePath          = appsBasePath + "/%s/schemas"
+	schemaValidationBasePath = appsBasePath + "/%s/validation_settings/schema"
+)
+
+// SchemasService provides access to the collection schemas related functions in the Realm API.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/schemas
+type SchemasService interface {
+	List(context.Context, string, string) ([]Schema, *Response, error)
+	Get(context.Context, string, string, string) (*Schema, *Response, error)
+	Create(context.Context, string, string, *Schema) (*Schema, *Response, error)
+	Update(context.Context, string, string, string, *Schema) (*Response, error)
+	Delete(context.Context, string, string, string) (*Response, error)
+	Validate(context.Context, string, string, *SchemaValidationRequest) (*SchemaValidationResult, *Response, error)
+}
+
+// SchemasServiceOp provides an implementation of the SchemasService interface.
+type SchemasServiceOp service
+
+var _ SchemasService = &SchemasServiceOp{}
+
+// List all collection schemas. Only the ID and metadata of each schema are returned.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/schemas
+func (s *SchemasServiceOp) List(ctx context.Context, groupID, appID string) ([]Schema, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	path := fmt.Sprintf(schemasBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var root []Schema
+	resp, err := s.Client.Do(ctx, req, &root)
+
+	return root, resp, err
+}
+
+// Get retrieves a single collection schema, including its relationships.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/schemas
+func (s *SchemasServiceOp) Get(ctx context.Context, groupID, appID, schemaID string) (*Schema, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if schemaID == "" {
+		return nil, nil, atlas.NewArgError("schemaID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(schemasBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, schemaID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Schema)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Create creates a collection schema.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/schemas
+func (s *SchemasServiceOp) Create(ctx context.Context, groupID, appID string, createRequest *Schema) (*Schema, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if createRequest == nil {
+		return nil, nil, atlas.NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf(schemasBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Schema)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Update replaces a collection schema.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/schemas
+func (s *SchemasServiceOp) Update(ctx context.Context, groupID, appID, schemaID string, updateRequest *Schema) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if schemaID == "" {
+		return nil, atlas.NewArgError("schemaID", "must be set")
+	}
+	if updateRequest == nil {
+		return nil, atlas.NewArgError("updateRequest", "cannot be nil")
+	}
+
+	basePath := fmt.Sprintf(schemasBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, schemaID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, path, updateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Delete deletes a collection schema.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/schemas
+func (s *SchemasServiceOp) Delete(ctx context.Context, groupID, appID, schemaID string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if schemaID == "" {
+		return nil, atlas.NewArgError("schemaID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(schemasBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, schemaID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Validate checks a schema against the documents already stored in a collection.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/schemas
+func (s *SchemasServiceOp) Validate(ctx context.Context, groupID, appID string, validateRequest *SchemaValidationRequest) (*SchemaValidationResult, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if validateRequest == nil {
+		return nil, nil, atlas.NewArgError("validateRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf(schemaValidationBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, validateRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(SchemaValidationResult)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Schema represents the JSON schema of a collection and its relationships to other collections.
+type Schema struct {
+	Schema *JSONSchema `json:"schema,omitempty"`
+	// Relationships are keyed by the path of the field holding the foreign key.
+	Relationships map[string]Relationship `json:"relationships,omitempty"`
+	Metadata      *SchemaMetadata         `json:"metadata,omitempty"`
+	ID            string                  `json:"_id,omitempty"`
+}
+
+// SchemaMetadata identifies the collection a schema applies to.
+type SchemaMetadata struct {
+	DataSource string `json:"data_source,omitempty"`
+	Database   string `json:"database,omitempty"`
+	Collection string `json:"collection,omitempty"`
+}
+
+// Relationship represents a link from a field to the documents of another collection.
+type Relationship struct {
+	// Ref is the JSON reference of the foreign collection, for example
+	// "#/relationship/mongodb-atlas/store/customers".
+	Ref        string `json:"ref,omitempty"`
+	ForeignKey string `json:"foreign_key,omitempty"`
+	IsList     bool   `json:"is_list"`
+}
+
+// JSONSchema represents an App Services JSON schema, which describes BSON
+// documents with bsonType in place of the JSON schema type keyword.
+type JSONSchema struct {
+	Properties map[string]*JSONSchema `json:"properties,omitempty"`
+	Items      *JSONSchema            `json:"items,omitempty"`
+	Enum       []interface{}          `json:"enum,omitempty"`
+	// AdditionalProperties is either a bool or a *JSONSchema.
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+	// BSONType is set when bsonType is a single type.
+	BSONType string `json:"-"`
+	// BSONTypes is set instead of BSONType when bsonType lists several types, as in ["string", "null"].
+	BSONTypes   []string `json:"-"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Required    []string `json:"required,omitempty"`
+	// Extra holds the keywords without a field, such as minimum, pattern or
+	// oneOf, so that they are preserved when the schema is sent back.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// jsonSchemaKeywords are the keywords decoded into the fields of JSONSchema.
+var jsonSchemaKeywords = []string{
+	"properties", "items", "enum", "additionalProperties", "bsonType", "title", "description", "required",
+}
+
+// MarshalJSON encodes the schema along with its Extra keywords.
+func (s *JSONSchema) MarshalJSON() ([]byte, error) {
+	type jsonSchema JSONSchema
+	aux := struct {
+		*jsonSchema
+		BSONType interface{} `json:"bsonType,omitempty"`
+	}{jsonSchema: (*jsonSchema)(s)}
+	switch {
+	case len(s.BSONTypes) > 0:
+		aux.BSONType = s.BSONTypes
+	case s.BSONType != "":
+		aux.BSONType = s.BSONType
+	}
+
+	data, err := json.Marshal(aux)
+	if err != nil || len(s.Extra) == 0 {
+		return data, err
+	}
+
+	var keywords map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keywords); err != nil {
+		return nil, err
+	}
+	for keyword, value := range s.Extra {
+		if _, ok := keywords[keyword]; !ok {
+			keywords[keyword] = value
+		}
+	}
+	return json.Marshal(keywords)
+}
+
+// UnmarshalJSON decodes a schema whose bsonType is either a string or an array
+// of strings, keeping the keywords without a field in Extra.
+func (s *JSONSchema) UnmarshalJSON(data []byte) error {
+	*s = JSONSchema{}
+
+	type jsonSchema JSONSchema
+	aux := struct {
+		*jsonSchema
+		BSONType json.RawMessage `json:"bsonType,omitempty"`
+	}{jsonSchema: (*jsonSchema)(s)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if len(aux.BSONType) > 0 && string(aux.BSONType) != "null" {
+		var err error
+		if aux.BSONType[0] == '[' {
+			err = json.Unmarshal(aux.BSONType, &s.BSONTypes)
+		} else {
+			err = json.Unmarshal(aux.BSONType, &s.BSONType)
+		}
+		if err != nil {
+			return fmt.Errorf("decoding bsonType: %w", err)
+		}
+	}
+
+	var keywords map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keywords); err != nil {
+		return err
+	}
+	for _, keyword := range jsonSchemaKeywords {
+		delete(keywords, keyword)
+	}
+	if len(keywords) > 0 {
+		s.Extra = keywords
+	}
+
+	return nil
+}
+
+// SchemaValidationRequest represents a request to validate a schema against the documents of a collection.
+type SchemaValidationRequest struct {
+	Schema   *JSONSchema     `json:"schema"`
+	Metadata *SchemaMetadata `json:"metadata"`
+	// Limit is the maximum number of documents to validate. Defaults to all documents.
+	Limit int `json:"limit,omitempty"`
+}
+
+// SchemaValidationResult represents the outcome of validating a schema against existing documents.
+type SchemaValidationResult struct {
+	Errors []SchemaValidationError `json:"errors,omitempty"`
+	// Total is the number of documents that were validated.
+	Total int `json:"total,omitempty"`
+}
+
+// Valid reports whether every validated document matched the schema.
+func (r *SchemaValidationResult) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// SchemaValidationError represents a schema violation shared by one or more documents.
+type SchemaValidationError struct {
+	Field       string   `json:"field,omitempty"`
+	Description string   `json:"description,omitempty"`
+	DocumentIDs []string `json:"document_ids,omitempty"`
+}
@@ -0,0 +1,312 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestSchemas_List(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/schemas", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[{"_id": "1", "metadata": {"data_source": "mongodb-atlas", "database": "store", "collection": "orders"}}]`)
+	})
+
+	schemas, _, err := client.Schemas.List(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("Schemas.List returned error: %v", err)
+	}
+
+	expected := []Schema{{
+		ID:       "1",
+		Metadata: &SchemaMetadata{DataSource: "mongodb-atlas", Database: "store", Collection: "orders"},
+	}}
+
+	if diff := deep.Equal(schemas, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestSchemas_Get(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	schemaID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/schemas/%s", groupID, appID, schemaID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{
+		  "_id": "1",
+		  "metadata": {"data_source": "mongodb-atlas", "database": "store", "collection": "orders"},
+		  "schema": {
+		    "title": "Order",
+		    "bsonType": "object",
+		    "required": ["_id", "customer_id"],
+		    "properties": {
+		      "_id": {"bsonType": "objectId"},
+		      "customer_id": {"bsonType": "objectId"},
+		      "items": {"bsonType": "array", "items": {"bsonType": "string"}}
+		    }
+		  },
+		  "relationships": {
+		    "customer_id": {"ref": "#/relationship/mongodb-atlas/store/customers", "foreign_key": "_id", "is_list": false}
+		  }
+		}`)
+	})
+
+	schema, _, err := client.Schemas.Get(ctx, groupID, appID, schemaID)
+	if err != nil {
+		t.Fatalf("Schemas.Get returned error: %v", err)
+	}
+
+	expected := &Schema{
+		ID:       "1",
+		Metadata: &SchemaMetadata{DataSource: "mongodb-atlas", Database: "store", Collection: "orders"},
+		Schema: &JSONSchema{
+			Title:    "Order",
+			BSONType: "object",
+			Required: []string{"_id", "customer_id"},
+			Properties: map[string]*JSONSchema{
+				"_id":         {BSONType: "objectId"},
+				"customer_id": {BSONType: "objectId"},
+				"items":       {BSONType: "array", Items: &JSONSchema{BSONType: "string"}},
+			},
+		},
+		Relationships: map[string]Relationship{
+			"customer_id": {Ref: "#/relationship/mongodb-atlas/store/customers", ForeignKey: "_id"},
+		},
+	}
+
+	if diff := deep.Equal(schema, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestSchemas_Create(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/schemas", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		expected := map[string]interface{}{
+			"metadata": map[string]interface{}{"data_source": "mongodb-atlas", "database": "store", "collection": "customers"},
+			"schema": map[string]interface{}{
+				"bsonType":   "object",
+				"properties": map[string]interface{}{"name": map[string]interface{}{"bsonType": "string"}},
+			},
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		fmt.Fprint(w, `{"_id": "2"}`)
+	})
+
+	createRequest := &Schema{
+		Metadata: &SchemaMetadata{DataSource: "mongodb-atlas", Database: "store", Collection: "customers"},
+		Schema: &JSONSchema{
+			BSONType:   "object",
+			Properties: map[string]*JSONSchema{"name": {BSONType: "string"}},
+		},
+	}
+
+	schema, _, err := client.Schemas.Create(ctx, groupID, appID, createRequest)
+	if err != nil {
+		t.Fatalf("Schemas.Create returned error: %v", err)
+	}
+
+	if schema.ID != "2" {
+		t.Errorf("expected schema ID 2, got %q", schema.ID)
+	}
+}
+
+func TestSchemas_Update(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	schemaID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/schemas/%s", groupID, appID, schemaID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Schemas.Update(ctx, groupID, appID, schemaID, &Schema{ID: schemaID, Schema: &JSONSchema{BSONType: "object"}})
+	if err != nil {
+		t.Fatalf("Schemas.Update returned error: %v", err)
+	}
+}
+
+func TestSchemas_Delete(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	schemaID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/schemas/%s", groupID, appID, schemaID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Schemas.Delete(ctx, groupID, appID, schemaID)
+	if err != nil {
+		t.Fatalf("Schemas.Delete returned error: %v", err)
+	}
+}
+
+func TestSchemas_Validate(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/validation_settings/schema", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		expected := map[string]interface{}{
+			"metadata": map[string]interface{}{"data_source": "mongodb-atlas", "database": "store", "collection": "orders"},
+			"schema":   map[string]interface{}{"bsonType": "object", "required": []interface{}{"total"}},
+			"limit":    float64(100),
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		fmt.Fprint(w, `{
+		  "total": 100,
+		  "errors": [{"field": "total", "description": "total is required", "document_ids": ["a", "b"]}]
+		}`)
+	})
+
+	validateRequest := &SchemaValidationRequest{
+		Metadata: &SchemaMetadata{DataSource: "mongodb-atlas", Database: "store", Collection: "orders"},
+		Schema:   &JSONSchema{BSONType: "object", Required: []string{"total"}},
+		Limit:    100,
+	}
+
+	result, _, err := client.Schemas.Validate(ctx, groupID, appID, validateRequest)
+	if err != nil {
+		t.Fatalf("Schemas.Validate returned error: %v", err)
+	}
+
+	expected := &SchemaValidationResult{
+		Total: 100,
+		Errors: []SchemaValidationError{
+			{Field: "total", Description: "total is required", DocumentIDs: []string{"a", "b"}},
+		},
+	}
+
+	if diff := deep.Equal(result, expected); diff != nil {
+		t.Error(diff)
+	}
+	if result.Valid() {
+		t.Error("expected the result to be invalid")
+	}
+}
+
+func TestJSONSchema_roundTrip(t *testing.T) {
+	input := `{
+	  "bsonType": "object",
+	  "title": "Order",
+	  "required": ["total"],
+	  "properties": {
+	    "total": {"bsonType": "double", "minimum": 0, "exclusiveMinimum": true},
+	    "note": {"bsonType": ["string", "null"], "maxLength": 200, "pattern": "^[a-z]*$"},
+	    "items": {"bsonType": "array", "maxItems": 10, "items": {"oneOf": [{"bsonType": "string"}, {"bsonType": "int"}]}}
+	  },
+	  "additionalProperties": false
+	}`
+
+	var schema JSONSchema
+	if err := json.Unmarshal([]byte(input), &schema); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if schema.BSONType != "object" || schema.BSONTypes != nil {
+		t.Errorf("expected bsonType object, got %q, %v", schema.BSONType, schema.BSONTypes)
+	}
+	note := schema.Properties["note"]
+	if diff := deep.Equal(note.BSONTypes, []string{"string", "null"}); diff != nil {
+		t.Error(diff)
+	}
+	if diff := deep.Equal(note.Extra, map[string]json.RawMessage{"maxLength": json.RawMessage("200"), "pattern": json.RawMessage(`"^[a-z]*$"`)}); diff != nil {
+		t.Error(diff)
+	}
+	if schema.Extra != nil {
+		t.Errorf("expected no extra keywords at the root, got %v", schema.Extra)
+	}
+
+	b, err := json.Marshal(&schema)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	var got, expected interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if err := json.Unmarshal([]byte(input), &expected); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if diff := deep.Equal(got, expected); diff != nil {
+		t.Error(diff)
+	}
+}
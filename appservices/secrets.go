@@ -0,0 +1,193 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	atlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	secretsBasePath = appsBasePath + "/%s/secrets"
+	redacted        = "[REDACTED]"
+)
+
+// SecretsService provides access to the secrets related functions in the Realm API.
+//
+// Secret values are write-only: the API never returns them, and requests that
+// carry them are neither copied to Response.Raw nor exposed to the
+// OnRequestCompleted callback.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/secrets
+type SecretsService interface {
+	List(context.Context, string, string) ([]Secret, *Response, error)
+	Create(context.Context, string, string, *SecretRequest) (*Secret, *Response, error)
+	Update(context.Context, string, string, string, *SecretRequest) (*Response, error)
+	Delete(context.Context, string, string, string) (*Response, error)
+}
+
+// SecretsServiceOp provides an implementation of the SecretsService interface.
+type SecretsServiceOp service
+
+var _ SecretsService = &SecretsServiceOp{}
+
+// List all secrets. Only their names are returned.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/secrets
+func (s *SecretsServiceOp) List(ctx context.Context, groupID, appID string) ([]Secret, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	path := fmt.Sprintf(secretsBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var root []Secret
+	resp, err := s.Client.Do(ctx, req, &root)
+
+	return root, resp, err
+}
+
+// Create creates a secret.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/secrets
+func (s *SecretsServiceOp) Create(ctx context.Context, groupID, appID string, createRequest *SecretRequest) (*Secret, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if createRequest == nil {
+		return nil, nil, atlas.NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf(secretsBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Secret)
+	resp, err := s.Client.Do(withSensitiveBody(ctx), req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Update renames a secret and/or replaces its value.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/secrets
+func (s *SecretsServiceOp) Update(ctx context.Context, groupID, appID, secretID string, updateRequest *SecretRequest) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if secretID == "" {
+		return nil, atlas.NewArgError("secretID", "must be set")
+	}
+	if updateRequest == nil {
+		return nil, atlas.NewArgError("updateRequest", "cannot be nil")
+	}
+
+	basePath := fmt.Sprintf(secretsBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, secretID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, path, updateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(withSensitiveBody(ctx), req, nil)
+}
+
+// Delete deletes a secret.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/secrets
+func (s *SecretsServiceOp) Delete(ctx context.Context, groupID, appID, secretID string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if secretID == "" {
+		return nil, atlas.NewArgError("secretID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(secretsBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, secretID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Secret represents an App Services secret. Its value is never returned by the API.
+type Secret struct {
+	LastModified *int64 `json:"last_modified,omitempty"`
+	ID           string `json:"_id,omitempty"`
+	Name         string `json:"name,omitempty"`
+}
+
+// SecretRequest represents a request to create or update a secret.
+type SecretRequest struct {
+	Name  string          `json:"name,omitempty"`
+	Value SensitiveString `json:"value,omitempty"`
+}
+
+// SensitiveString is a string that is redacted whenever it is formatted with
+// the fmt package or logged with log/slog. It is JSON encoded as is, so it can
+// be sent to the API. Convert it to a string to read its content.
+//
+// The requests that send a SensitiveString, and the responses that return one,
+// are neither copied to Response.Raw nor exposed to the OnRequestCompleted
+// callback, and the returned Response cannot replay their body.
+type SensitiveString string
+
+// String implements fmt.Stringer.
+func (SensitiveString) String() string {
+	return redacted
+}
+
+// Format implements fmt.Formatter, so every verb, including %#v, is redacted.
+func (SensitiveString) Format(f fmt.State, _ rune) {
+	_, _ = io.WriteString(f, redacted)
+}
+
+// LogValue implements slog.LogValuer.
+func (SensitiveString) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
@@ -0,0 +1,187 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestSecrets_List(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/secrets", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[{"_id": "1", "name": "apiKeySecret", "last_modified": 1615840000}]`)
+	})
+
+	secrets, _, err := client.Secrets.List(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("Secrets.List returned error: %v", err)
+	}
+
+	expected := []Secret{
+		{ID: "1", Name: "apiKeySecret", LastModified: pointer[int64](1615840000)},
+	}
+
+	if diff := deep.Equal(secrets, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestSecrets_Create(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/secrets", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		expected := map[string]interface{}{
+			"name":  "apiKeySecret",
+			"value": "s3cr3t",
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		fmt.Fprint(w, `{"_id": "1", "name": "apiKeySecret"}`)
+	})
+
+	secret, _, err := client.Secrets.Create(ctx, groupID, appID, &SecretRequest{Name: "apiKeySecret", Value: "s3cr3t"})
+	if err != nil {
+		t.Fatalf("Secrets.Create returned error: %v", err)
+	}
+
+	expected := &Secret{ID: "1", Name: "apiKeySecret"}
+
+	if diff := deep.Equal(secret, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestSecrets_Update_redacted(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	client.withRaw = true
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	secretID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/secrets/%s", groupID, appID, secretID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		if b, _ := io.ReadAll(r.Body); !strings.Contains(string(b), "s3cr3t") {
+			t.Errorf("request body = %s, expected it to contain the secret value", b)
+		}
+		// Echo the value back to make sure it does not reach Response.Raw.
+		fmt.Fprint(w, `{"value": "s3cr3t"}`)
+	})
+
+	var dumped string
+	client.OnRequestCompleted(func(req *http.Request, resp *http.Response) {
+		testRedactedRequest(t, resp.Request, "s3cr3t")
+		b, err := httputil.DumpRequestOut(req, true)
+		if err != nil {
+			t.Errorf("DumpRequestOut: %v", err)
+		}
+		dumped = string(b)
+		if req.GetBody != nil {
+			t.Error("OnRequestCompleted received a request whose body can be re-read")
+		}
+	})
+
+	resp, err := client.Secrets.Update(ctx, groupID, appID, secretID, &SecretRequest{Name: "apiKeySecret", Value: "s3cr3t"})
+	if err != nil {
+		t.Fatalf("Secrets.Update returned error: %v", err)
+	}
+
+	if strings.Contains(dumped, "s3cr3t") {
+		t.Errorf("OnRequestCompleted exposed the secret value: %s", dumped)
+	}
+	testRedactedRequest(t, resp.Request, "s3cr3t")
+	if resp.Raw != nil {
+		t.Errorf("Response.Raw = %s, expected nil", resp.Raw)
+	}
+}
+
+func TestSecrets_Delete(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	secretID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/secrets/%s", groupID, appID, secretID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Secrets.Delete(ctx, groupID, appID, secretID)
+	if err != nil {
+		t.Fatalf("Secrets.Delete returned error: %v", err)
+	}
+}
+
+func TestSensitiveString(t *testing.T) {
+	r := &SecretRequest{Name: "apiKeySecret", Value: "s3cr3t"}
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x"} {
+		if got := fmt.Sprintf(format, r); strings.Contains(got, "s3cr3t") || strings.Contains(got, fmt.Sprintf("%x", "s3cr3t")) {
+			t.Errorf("Sprintf(%q) = %v, expected the value to be redacted", format, got)
+		}
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("rotating", "secret", r.Value)
+	if strings.Contains(buf.String(), "s3cr3t") {
+		t.Errorf("slog output = %v, expected the value to be redacted", buf.String())
+	}
+
+	if string(r.Value) != "s3cr3t" {
+		t.Errorf("string(Value) = %v, expected s3cr3t", string(r.Value))
+	}
+}
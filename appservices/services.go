@@ -0,0 +1,291 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	atlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	servicesBasePath = appsBasePath + "/%s/services"
+)
+
+// Service types with a typed configuration.
+const (
+	ServiceTypeMongoDBAtlas = "mongodb-atlas"
+	ServiceTypeDataLake     = "datalake"
+	ServiceTypeHTTP         = "http"
+)
+
+// ServicesService provides access to the data sources and services related functions in the Realm API.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/services
+type ServicesService interface {
+	List(context.Context, string, string) ([]Service, *Response, error)
+	Get(context.Context, string, string, string) (*Service, *Response, error)
+	Create(context.Context, string, string, *Service) (*Service, *Response, error)
+	Update(context.Context, string, string, string, *Service) (*Response, error)
+	Delete(context.Context, string, string, string) (*Response, error)
+}
+
+// ServicesServiceOp provides an implementation of the ServicesService interface.
+type ServicesServiceOp service
+
+var _ ServicesService = &ServicesServiceOp{}
+
+// List all data sources and services. The configuration of each service is not returned.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/services
+func (s *ServicesServiceOp) List(ctx context.Context, groupID, appID string) ([]Service, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	path := fmt.Sprintf(servicesBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var root []Service
+	resp, err := s.Client.Do(ctx, req, &root)
+
+	return root, resp, err
+}
+
+// Get retrieves a single data source or service.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/services
+func (s *ServicesServiceOp) Get(ctx context.Context, groupID, appID, serviceID string) (*Service, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if serviceID == "" {
+		return nil, nil, atlas.NewArgError("serviceID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(servicesBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, serviceID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Service)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Create creates a data source or service.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/services
+func (s *ServicesServiceOp) Create(ctx context.Context, groupID, appID string, createRequest *Service) (*Service, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if createRequest == nil {
+		return nil, nil, atlas.NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf(servicesBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Service)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Update replaces a data source or service.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/services
+func (s *ServicesServiceOp) Update(ctx context.Context, groupID, appID, serviceID string, updateRequest *Service) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if serviceID == "" {
+		return nil, atlas.NewArgError("serviceID", "must be set")
+	}
+	if updateRequest == nil {
+		return nil, atlas.NewArgError("updateRequest", "cannot be nil")
+	}
+
+	basePath := fmt.Sprintf(servicesBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, serviceID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, path, updateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Delete deletes a data source or service.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/services
+func (s *ServicesServiceOp) Delete(ctx context.Context, groupID, appID, serviceID string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if serviceID == "" {
+		return nil, atlas.NewArgError("serviceID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(servicesBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, serviceID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Service represents an App Services data source or service. The ID of a
+// mongodb-atlas service is the ServiceID referenced by trigger configurations.
+type Service struct {
+	// Config is a *MongoDBAtlasConfig, *DataLakeConfig or *HTTPConfig depending
+	// on Type, and a RawServiceConfig for any other service type.
+	Config       ServiceConfig `json:"config,omitempty"`
+	Version      int           `json:"version,omitempty"`
+	LastModified *int64        `json:"last_modified,omitempty"`
+	ID           string        `json:"_id,omitempty"`
+	Name         string        `json:"name,omitempty"`
+	Type         string        `json:"type,omitempty"`
+}
+
+// NewService returns a service of the type matching config.
+func NewService(name string, config ServiceConfig) *Service {
+	return &Service{Name: name, Type: config.ServiceType(), Config: config}
+}
+
+// UnmarshalJSON decodes the service configuration into the type matching the service type.
+func (s *Service) UnmarshalJSON(data []byte) error {
+	type service Service
+	aux := struct {
+		*service
+		Config json.RawMessage `json:"config,omitempty"`
+	}{service: (*service)(s)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	s.Config = nil
+	if len(aux.Config) == 0 || string(aux.Config) == "null" {
+		return nil
+	}
+
+	var config ServiceConfig
+	switch s.Type {
+	case ServiceTypeMongoDBAtlas:
+		config = new(MongoDBAtlasConfig)
+	case ServiceTypeDataLake:
+		config = new(DataLakeConfig)
+	case ServiceTypeHTTP:
+		config = new(HTTPConfig)
+	default:
+		config = &RawServiceConfig{Type: s.Type}
+	}
+	if err := json.Unmarshal(aux.Config, config); err != nil {
+		return fmt.Errorf("decoding %s service config: %w", s.Type, err)
+	}
+	s.Config = config
+
+	return nil
+}
+
+// ServiceConfig is implemented by the configuration of each service type.
+type ServiceConfig interface {
+	// ServiceType returns the service type the configuration applies to.
+	ServiceType() string
+}
+
+// MongoDBAtlasConfig represents the configuration of a linked Atlas cluster.
+type MongoDBAtlasConfig struct {
+	WireProtocolEnabled *bool  `json:"wireProtocolEnabled,omitempty"`
+	ClusterName         string `json:"clusterName,omitempty"`
+	ReadPreference      string `json:"readPreference,omitempty"`
+}
+
+// ServiceType returns ServiceTypeMongoDBAtlas.
+func (*MongoDBAtlasConfig) ServiceType() string { return ServiceTypeMongoDBAtlas }
+
+// DataLakeConfig represents the configuration of a linked Atlas Data Federation instance.
+type DataLakeConfig struct {
+	DataLakeName string `json:"dataLakeName,omitempty"`
+}
+
+// ServiceType returns ServiceTypeDataLake.
+func (*DataLakeConfig) ServiceType() string { return ServiceTypeDataLake }
+
+// HTTPConfig represents the configuration of an HTTP service, which has no settings.
+type HTTPConfig struct{}
+
+// ServiceType returns ServiceTypeHTTP.
+func (*HTTPConfig) ServiceType() string { return ServiceTypeHTTP }
+
+// RawServiceConfig holds the configuration of a service type without a typed configuration.
+type RawServiceConfig struct {
+	Type   string
+	Config map[string]interface{}
+}
+
+// ServiceType returns the service type the configuration was decoded for.
+func (c *RawServiceConfig) ServiceType() string { return c.Type }
+
+// MarshalJSON encodes the configuration settings.
+func (c *RawServiceConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Config)
+}
+
+// UnmarshalJSON decodes the configuration settings.
+func (c *RawServiceConfig) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &c.Config)
+}
@@ -0,0 +1,242 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestServices_List(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/services", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[
+		  {"_id": "1", "name": "mongodb-atlas", "type": "mongodb-atlas", "version": 1},
+		  {"_id": "2", "name": "http", "type": "http", "version": 1}
+		]`)
+	})
+
+	services, _, err := client.Services.List(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("Services.List returned error: %v", err)
+	}
+
+	expected := []Service{
+		{ID: "1", Name: "mongodb-atlas", Type: ServiceTypeMongoDBAtlas, Version: 1},
+		{ID: "2", Name: "http", Type: ServiceTypeHTTP, Version: 1},
+	}
+
+	if diff := deep.Equal(services, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestServices_Get(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	tests := map[string]struct {
+		body     string
+		expected *Service
+	}{
+		"mongodb-atlas": {
+			body: `{
+			  "_id": "mongodb-atlas",
+			  "name": "mongodb-atlas",
+			  "type": "mongodb-atlas",
+			  "config": {"clusterName": "Cluster0", "readPreference": "secondary", "wireProtocolEnabled": true}
+			}`,
+			expected: &Service{
+				ID:   "mongodb-atlas",
+				Name: "mongodb-atlas",
+				Type: ServiceTypeMongoDBAtlas,
+				Config: &MongoDBAtlasConfig{
+					ClusterName:         "Cluster0",
+					ReadPreference:      "secondary",
+					WireProtocolEnabled: pointer(true),
+				},
+			},
+		},
+		"datalake": {
+			body: `{"_id": "datalake", "name": "datalake", "type": "datalake", "config": {"dataLakeName": "lake"}}`,
+			expected: &Service{
+				ID:     "datalake",
+				Name:   "datalake",
+				Type:   ServiceTypeDataLake,
+				Config: &DataLakeConfig{DataLakeName: "lake"},
+			},
+		},
+		"http": {
+			body: `{"_id": "http", "name": "http", "type": "http", "config": {}}`,
+			expected: &Service{
+				ID:     "http",
+				Name:   "http",
+				Type:   ServiceTypeHTTP,
+				Config: &HTTPConfig{},
+			},
+		},
+		"other": {
+			body: `{"_id": "other", "name": "twilio", "type": "twilio", "config": {"sid": "AC123"}}`,
+			expected: &Service{
+				ID:     "other",
+				Name:   "twilio",
+				Type:   "twilio",
+				Config: &RawServiceConfig{Type: "twilio", Config: map[string]interface{}{"sid": "AC123"}},
+			},
+		},
+	}
+
+	for serviceID, tc := range tests {
+		body := tc.body
+		mux.HandleFunc(fmt.Sprintf("/groups/%s/apps/%s/services/%s", groupID, appID, serviceID), func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodGet)
+			fmt.Fprint(w, body)
+		})
+
+		service, _, err := client.Services.Get(ctx, groupID, appID, serviceID)
+		if err != nil {
+			t.Fatalf("Services.Get(%s) returned error: %v", serviceID, err)
+		}
+
+		if diff := deep.Equal(service, tc.expected); diff != nil {
+			t.Errorf("%s: %v", serviceID, diff)
+		}
+	}
+}
+
+func TestServices_Create(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/services", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		expected := map[string]interface{}{
+			"name": "mongodb-atlas",
+			"type": "mongodb-atlas",
+			"config": map[string]interface{}{
+				"clusterName":         "Cluster0",
+				"wireProtocolEnabled": false,
+			},
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		fmt.Fprint(w, `{"_id": "1", "name": "mongodb-atlas", "type": "mongodb-atlas", "version": 1}`)
+	})
+
+	createRequest := NewService("mongodb-atlas", &MongoDBAtlasConfig{
+		ClusterName:         "Cluster0",
+		WireProtocolEnabled: pointer(false),
+	})
+
+	service, _, err := client.Services.Create(ctx, groupID, appID, createRequest)
+	if err != nil {
+		t.Fatalf("Services.Create returned error: %v", err)
+	}
+
+	expected := &Service{ID: "1", Name: "mongodb-atlas", Type: ServiceTypeMongoDBAtlas, Version: 1}
+
+	if diff := deep.Equal(service, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestServices_Update(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	serviceID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/services/%s", groupID, appID, serviceID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		expected := map[string]interface{}{
+			"name":   "twilio",
+			"type":   "twilio",
+			"config": map[string]interface{}{"sid": "AC456"},
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	updateRequest := NewService("twilio", &RawServiceConfig{Type: "twilio", Config: map[string]interface{}{"sid": "AC456"}})
+
+	_, err := client.Services.Update(ctx, groupID, appID, serviceID, updateRequest)
+	if err != nil {
+		t.Fatalf("Services.Update returned error: %v", err)
+	}
+}
+
+func TestServices_Delete(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	serviceID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/services/%s", groupID, appID, serviceID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Services.Delete(ctx, groupID, appID, serviceID)
+	if err != nil {
+		t.Fatalf("Services.Delete returned error: %v", err)
+	}
+}
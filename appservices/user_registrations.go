@@ -0,0 +1,235 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	atlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	userRegistrationsBasePath = appsBasePath + "/%s/user_registrations"
+	loginIDTypeEmail          = "email"
+)
+
+// UserRegistrationsService provides access to the pending email/password users
+// of the local-userpass provider in the Realm API. A user is pending until it
+// confirms its email address, or is confirmed by an administrator.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/email-password-users
+type UserRegistrationsService interface {
+	ListPending(context.Context, string, string, *PendingUserListOptions) ([]PendingUser, *Response, error)
+	GetByEmail(context.Context, string, string, string) (*PendingUser, *Response, error)
+	Confirm(context.Context, string, string, string) (*Response, error)
+	ResendConfirmation(context.Context, string, string, string) (*Response, error)
+	RunConfirmation(context.Context, string, string, string) (*Response, error)
+	DeletePending(context.Context, string, string, string) (*Response, error)
+	SendPasswordReset(context.Context, string, string, string) (*Response, error)
+	RunPasswordReset(context.Context, string, string, string, *PasswordResetRequest) (*Response, error)
+}
+
+// UserRegistrationsServiceOp provides an implementation of the UserRegistrationsService interface.
+type UserRegistrationsServiceOp service
+
+var _ UserRegistrationsService = &UserRegistrationsServiceOp{}
+
+// ListPending lists a page of the pending users. To get the next page, set
+// PendingUserListOptions.After to the ID of the last pending user returned.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/email-password-users
+func (s *UserRegistrationsServiceOp) ListPending(ctx context.Context, groupID, appID string, opts *PendingUserListOptions) ([]PendingUser, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(userRegistrationsBasePath, groupID, appID)
+	path, err := setQueryParams(basePath+"/pending_users", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var root []PendingUser
+	resp, err := s.Client.Do(ctx, req, &root)
+
+	return root, resp, err
+}
+
+// GetByEmail retrieves the pending user registered with email.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/email-password-users
+func (s *UserRegistrationsServiceOp) GetByEmail(ctx context.Context, groupID, appID, email string) (*PendingUser, *Response, error) {
+	path, err := byEmailPath(groupID, appID, email, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(PendingUser)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Confirm confirms the pending user registered with email without it
+// following the confirmation link, turning it into a regular user.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/email-password-users
+func (s *UserRegistrationsServiceOp) Confirm(ctx context.Context, groupID, appID, email string) (*Response, error) {
+	return s.post(ctx, groupID, appID, email, "confirm", nil)
+}
+
+// ResendConfirmation sends the confirmation email to the pending user registered with email again.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/email-password-users
+func (s *UserRegistrationsServiceOp) ResendConfirmation(ctx context.Context, groupID, appID, email string) (*Response, error) {
+	return s.post(ctx, groupID, appID, email, "send_confirm", nil)
+}
+
+// RunConfirmation runs the confirmation function of the provider again for the pending user registered with email.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/email-password-users
+func (s *UserRegistrationsServiceOp) RunConfirmation(ctx context.Context, groupID, appID, email string) (*Response, error) {
+	return s.post(ctx, groupID, appID, email, "run_confirm", nil)
+}
+
+// DeletePending deletes the pending user registered with email, so the address can register again.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/email-password-users
+func (s *UserRegistrationsServiceOp) DeletePending(ctx context.Context, groupID, appID, email string) (*Response, error) {
+	path, err := byEmailPath(groupID, appID, email, "")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// SendPasswordReset sends the password reset email to the user registered with email.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/email-password-users
+func (s *UserRegistrationsServiceOp) SendPasswordReset(ctx context.Context, groupID, appID, email string) (*Response, error) {
+	return s.post(ctx, groupID, appID, email, "send_reset_password_email", nil)
+}
+
+// RunPasswordReset runs the password reset function of the provider for the
+// user registered with email.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/email-password-users
+func (s *UserRegistrationsServiceOp) RunPasswordReset(ctx context.Context, groupID, appID, email string, resetRequest *PasswordResetRequest) (*Response, error) {
+	if resetRequest == nil {
+		return nil, atlas.NewArgError("resetRequest", "cannot be nil")
+	}
+
+	return s.post(withSensitiveBody(ctx), groupID, appID, email, "run_reset_password_func", resetRequest)
+}
+
+func (s *UserRegistrationsServiceOp) post(ctx context.Context, groupID, appID, email, action string, body interface{}) (*Response, error) {
+	path, err := byEmailPath(groupID, appID, email, action)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// byEmailPath returns the path of the registration of email, followed by action if set.
+func byEmailPath(groupID, appID, email, action string) (string, error) {
+	if groupID == "" {
+		return "", atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return "", atlas.NewArgError("appID", "must be set")
+	}
+	if email == "" {
+		return "", atlas.NewArgError("email", "must be set")
+	}
+
+	basePath := fmt.Sprintf(userRegistrationsBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/by_email/%s", basePath, url.PathEscape(email))
+	if action != "" {
+		path += "/" + action
+	}
+
+	return path, nil
+}
+
+// PendingUserListOptions specifies the optional parameters to the UserRegistrationsService.ListPending method.
+type PendingUserListOptions struct {
+	// After only returns the pending users after the pending user with this ID.
+	After string `url:"after,omitempty"`
+	// Limit is the maximum number of pending users to return.
+	Limit int `url:"limit,omitempty"`
+}
+
+// PendingUser represents an email/password user that has not been confirmed yet.
+type PendingUser struct {
+	ID       string    `json:"_id,omitempty"`
+	UserID   string    `json:"user_id,omitempty"`
+	DomainID string    `json:"domain_id,omitempty"`
+	LoginIDs []LoginID `json:"login_ids,omitempty"`
+}
+
+// Email returns the email address the user registered with.
+func (u *PendingUser) Email() string {
+	for _, id := range u.LoginIDs {
+		if id.IDType == loginIDTypeEmail {
+			return id.ID
+		}
+	}
+	return ""
+}
+
+// LoginID represents an identifier a pending user registered with.
+type LoginID struct {
+	IDType    string `json:"id_type,omitempty"`
+	ID        string `json:"id,omitempty"`
+	Confirmed bool   `json:"confirmed,omitempty"`
+}
+
+// PasswordResetRequest represents a request to run the password reset function.
+type PasswordResetRequest struct {
+	Password SensitiveString `json:"password"`
+	// Arguments are passed to the password reset function after the password.
+	Arguments []interface{} `json:"arguments,omitempty"`
+}
@@ -0,0 +1,192 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestUserRegistrations_ListPending(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/user_registrations/pending_users", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		expected := map[string][]string{"after": {"1"}, "limit": {"50"}}
+		if diff := deep.Equal(map[string][]string(r.URL.Query()), expected); diff != nil {
+			t.Error(diff)
+		}
+		fmt.Fprint(w, `[{
+		  "_id": "2",
+		  "user_id": "u2",
+		  "domain_id": "d1",
+		  "login_ids": [{"id_type": "email", "id": "jane@example.com", "confirmed": false}]
+		}]`)
+	})
+
+	users, _, err := client.UserRegistrations.ListPending(ctx, groupID, appID, &PendingUserListOptions{After: "1", Limit: 50})
+	if err != nil {
+		t.Fatalf("UserRegistrations.ListPending returned error: %v", err)
+	}
+
+	expected := []PendingUser{{
+		ID:       "2",
+		UserID:   "u2",
+		DomainID: "d1",
+		LoginIDs: []LoginID{{IDType: "email", ID: "jane@example.com"}},
+	}}
+
+	if diff := deep.Equal(users, expected); diff != nil {
+		t.Error(diff)
+	}
+	if email := users[0].Email(); email != "jane@example.com" {
+		t.Errorf("expected email jane@example.com, got %q", email)
+	}
+}
+
+func TestUserRegistrations_GetByEmail(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/user_registrations/by_email/jane+test@example.com", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"_id": "2", "login_ids": [{"id_type": "email", "id": "jane+test@example.com"}]}`)
+	})
+
+	user, _, err := client.UserRegistrations.GetByEmail(ctx, groupID, appID, "jane+test@example.com")
+	if err != nil {
+		t.Fatalf("UserRegistrations.GetByEmail returned error: %v", err)
+	}
+
+	expected := &PendingUser{ID: "2", LoginIDs: []LoginID{{IDType: "email", ID: "jane+test@example.com"}}}
+
+	if diff := deep.Equal(user, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestUserRegistrations_actions(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	email := "jane@example.com"
+
+	basePath := fmt.Sprintf("/groups/%s/apps/%s/user_registrations/by_email/%s", groupID, appID, email)
+
+	var calls []string
+	mux.HandleFunc(basePath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		calls = append(calls, "delete")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	for _, action := range []string{"confirm", "send_confirm", "run_confirm", "send_reset_password_email"} {
+		mux.HandleFunc(basePath+"/"+action, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodPost)
+			calls = append(calls, action)
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+
+	if _, err := client.UserRegistrations.Confirm(ctx, groupID, appID, email); err != nil {
+		t.Fatalf("UserRegistrations.Confirm returned error: %v", err)
+	}
+	if _, err := client.UserRegistrations.ResendConfirmation(ctx, groupID, appID, email); err != nil {
+		t.Fatalf("UserRegistrations.ResendConfirmation returned error: %v", err)
+	}
+	if _, err := client.UserRegistrations.RunConfirmation(ctx, groupID, appID, email); err != nil {
+		t.Fatalf("UserRegistrations.RunConfirmation returned error: %v", err)
+	}
+	if _, err := client.UserRegistrations.SendPasswordReset(ctx, groupID, appID, email); err != nil {
+		t.Fatalf("UserRegistrations.SendPasswordReset returned error: %v", err)
+	}
+	if _, err := client.UserRegistrations.DeletePending(ctx, groupID, appID, email); err != nil {
+		t.Fatalf("UserRegistrations.DeletePending returned error: %v", err)
+	}
+
+	expected := []string{"confirm", "send_confirm", "run_confirm", "send_reset_password_email", "delete"}
+	if diff := deep.Equal(calls, expected); diff != nil {
+		t.Error(diff)
+	}
+
+	if _, err := client.UserRegistrations.Confirm(ctx, groupID, appID, ""); err == nil {
+		t.Error("expected an error for an empty email")
+	}
+}
+
+func TestUserRegistrations_RunPasswordReset(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	client.withRaw = true
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	email := "jane@example.com"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/user_registrations/by_email/%s/run_reset_password_func", groupID, appID, email)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		expected := map[string]interface{}{
+			"password":  "n3wpassw0rd",
+			"arguments": []interface{}{"en"},
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		fmt.Fprint(w, `{"status": "success"}`)
+	})
+
+	client.OnRequestCompleted(func(req *http.Request, resp *http.Response) {
+		testRedactedRequest(t, req, "n3wpassw0rd")
+		testRedactedRequest(t, resp.Request, "n3wpassw0rd")
+	})
+
+	resetRequest := &PasswordResetRequest{Password: "n3wpassw0rd", Arguments: []interface{}{"en"}}
+
+	resp, err := client.UserRegistrations.RunPasswordReset(ctx, groupID, appID, email, resetRequest)
+	if err != nil {
+		t.Fatalf("UserRegistrations.RunPasswordReset returned error: %v", err)
+	}
+	testRedactedRequest(t, resp.Request, "n3wpassw0rd")
+	if resp.Raw != nil {
+		t.Errorf("Response.Raw = %s, expected nil", resp.Raw)
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	atlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	usersBasePath = appsBasePath + "/%s/users"
+)
+
+// UsersService provides access to the app users related functions in the Realm API.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/users
+type UsersService interface {
+	List(context.Context, string, string, *UserListOptions) ([]User, *Response, error)
+	Get(context.Context, string, string, string) (*User, *Response, error)
+	Create(context.Context, string, string, *UserRequest) (*User, *Response, error)
+	Delete(context.Context, string, string, string) (*Response, error)
+	Enable(context.Context, string, string, string) (*Response, error)
+	Disable(context.Context, string, string, string) (*Response, error)
+	RevokeSessions(context.Context, string, string, string) (*Response, error)
+	ListDevices(context.Context, string, string, string) ([]UserDevice, *Response, error)
+}
+
+// UsersServiceOp provides an implementation of the UsersService interface.
+type UsersServiceOp service
+
+var _ UsersService = &UsersServiceOp{}
+
+// List a page of the users of an app. To get the next page, set
+// UserListOptions.After to the ID of the last user returned; an empty page
+// means there are no more users.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/users
+func (s *UsersServiceOp) List(ctx context.Context, groupID, appID string, opts *UserListOptions) ([]User, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(usersBasePath, groupID, appID)
+	path, err := setQueryParams(basePath, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var root []User
+	resp, err := s.Client.Do(ctx, req, &root)
+
+	return root, resp, err
+}
+
+// Get retrieves a single user.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/users
+func (s *UsersServiceOp) Get(ctx context.Context, groupID, appID, userID string) (*User, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if userID == "" {
+		return nil, nil, atlas.NewArgError("userID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(usersBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, userID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(User)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Create creates a confirmed email/password user.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/users
+func (s *UsersServiceOp) Create(ctx context.Context, groupID, appID string, createRequest *UserRequest) (*User, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if createRequest == nil {
+		return nil, nil, atlas.NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf(usersBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(User)
+	resp, err := s.Client.Do(withSensitiveBody(ctx), req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Delete deletes a user.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/users
+func (s *UsersServiceOp) Delete(ctx context.Context, groupID, appID, userID string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if userID == "" {
+		return nil, atlas.NewArgError("userID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(usersBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, userID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Enable enables a user.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/users
+func (s *UsersServiceOp) Enable(ctx context.Context, groupID, appID, userID string) (*Response, error) {
+	return s.userAction(ctx, groupID, appID, userID, "enable")
+}
+
+// Disable disables a user. A disabled user cannot log in, but existing
+// sessions stay valid until they are revoked with RevokeSessions.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/users
+func (s *UsersServiceOp) Disable(ctx context.Context, groupID, appID, userID string) (*Response, error) {
+	return s.userAction(ctx, groupID, appID, userID, "disable")
+}
+
+// RevokeSessions logs a user out of every device by revoking all its sessions.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/users
+func (s *UsersServiceOp) RevokeSessions(ctx context.Context, groupID, appID, userID string) (*Response, error) {
+	return s.userAction(ctx, groupID, appID, userID, "logout")
+}
+
+func (s *UsersServiceOp) userAction(ctx context.Context, groupID, appID, userID, action string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if userID == "" {
+		return nil, atlas.NewArgError("userID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(usersBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s/%s", basePath, userID, action)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// ListDevices lists the devices a user has logged in from.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/users
+func (s *UsersServiceOp) ListDevices(ctx context.Context, groupID, appID, userID string) ([]UserDevice, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if userID == "" {
+		return nil, nil, atlas.NewArgError("userID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(usersBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s/devices", basePath, userID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var root []UserDevice
+	resp, err := s.Client.Do(ctx, req, &root)
+
+	return root, resp, err
+}
+
+// UserListOptions specifies the optional parameters to the UsersService.List method.
+type UserListOptions struct {
+	// After only returns the users after the user with this ID, in sort order.
+	After string `url:"after,omitempty"`
+	// Sort is the field to sort users by, such as "_id", the default.
+	Sort string `url:"sort,omitempty"`
+	// Desc sorts users in descending order.
+	Desc bool `url:"desc,omitempty"`
+}
+
+// User represents an app user.
+type User struct {
+	Data                   map[string]interface{} `json:"data,omitempty"`
+	CustomData             map[string]interface{} `json:"custom_data,omitempty"`
+	CreationDate           *int64                 `json:"creation_date,omitempty"`
+	LastAuthenticationDate *int64                 `json:"last_authentication_date,omitempty"`
+	ID                     string                 `json:"_id,omitempty"`
+	Type                   string                 `json:"type,omitempty"`
+	Identities             []UserIdentity         `json:"identities,omitempty"`
+	Disabled               bool                   `json:"disabled,omitempty"`
+}
+
+// UserIdentity represents the identity a user has with an authentication provider.
+type UserIdentity struct {
+	ProviderData map[string]interface{} `json:"provider_data,omitempty"`
+	ID           string                 `json:"id,omitempty"`
+	ProviderType string                 `json:"provider_type,omitempty"`
+	ProviderID   string                 `json:"provider_id,omitempty"`
+}
+
+// UserRequest represents a request to create an email/password user.
+type UserRequest struct {
+	Email    string          `json:"email"`
+	Password SensitiveString `json:"password"`
+}
+
+// UserDevice represents a device a user has logged in from.
+type UserDevice struct {
+	ID              string `json:"device_id,omitempty"`
+	AppID           string `json:"app_id,omitempty"`
+	AppVersion      string `json:"app_version,omitempty"`
+	Platform        string `json:"platform,omitempty"`
+	PlatformVersion string `json:"platform_version,omitempty"`
+	SDKVersion      string `json:"sdk_version,omitempty"`
+}
@@ -0,0 +1,248 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestUsers_List(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/users", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		expected := map[string][]string{"after": {"1"}, "sort": {"_id"}, "desc": {"true"}}
+		if diff := deep.Equal(map[string][]string(r.URL.Query()), expected); diff != nil {
+			t.Error(diff)
+		}
+		fmt.Fprint(w, `[{
+		  "_id": "2",
+		  "type": "normal",
+		  "creation_date": 1615840000,
+		  "last_authentication_date": 1615850000,
+		  "disabled": false,
+		  "identities": [{"id": "i1", "provider_type": "local-userpass", "provider_id": "p1", "provider_data": {"email": "jane@example.com"}}],
+		  "data": {"email": "jane@example.com"},
+		  "custom_data": {"plan": "pro"}
+		}]`)
+	})
+
+	users, _, err := client.Users.List(ctx, groupID, appID, &UserListOptions{After: "1", Sort: "_id", Desc: true})
+	if err != nil {
+		t.Fatalf("Users.List returned error: %v", err)
+	}
+
+	expected := []User{{
+		ID:                     "2",
+		Type:                   "normal",
+		CreationDate:           pointer[int64](1615840000),
+		LastAuthenticationDate: pointer[int64](1615850000),
+		Identities: []UserIdentity{{
+			ID:           "i1",
+			ProviderType: AuthProviderTypeLocalUserPass,
+			ProviderID:   "p1",
+			ProviderData: map[string]interface{}{"email": "jane@example.com"},
+		}},
+		Data:       map[string]interface{}{"email": "jane@example.com"},
+		CustomData: map[string]interface{}{"plan": "pro"},
+	}}
+
+	if diff := deep.Equal(users, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestUsers_Get(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	userID := "2"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/users/%s", groupID, appID, userID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"_id": "2", "type": "server", "disabled": true}`)
+	})
+
+	user, _, err := client.Users.Get(ctx, groupID, appID, userID)
+	if err != nil {
+		t.Fatalf("Users.Get returned error: %v", err)
+	}
+
+	expected := &User{ID: "2", Type: "server", Disabled: true}
+
+	if diff := deep.Equal(user, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestUsers_Create(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	client.withRaw = true
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/users", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		expected := map[string]interface{}{"email": "jane@example.com", "password": "hunter22"}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		fmt.Fprint(w, `{"_id": "2", "type": "normal"}`)
+	})
+
+	client.OnRequestCompleted(func(req *http.Request, resp *http.Response) {
+		testRedactedRequest(t, req, "hunter22")
+		testRedactedRequest(t, resp.Request, "hunter22")
+	})
+
+	user, resp, err := client.Users.Create(ctx, groupID, appID, &UserRequest{Email: "jane@example.com", Password: "hunter22"})
+	if err != nil {
+		t.Fatalf("Users.Create returned error: %v", err)
+	}
+	testRedactedRequest(t, resp.Request, "hunter22")
+
+	expected := &User{ID: "2", Type: "normal"}
+
+	if diff := deep.Equal(user, expected); diff != nil {
+		t.Error(diff)
+	}
+	if resp.Raw != nil {
+		t.Errorf("Response.Raw = %s, expected nil", resp.Raw)
+	}
+}
+
+func TestUsers_Delete(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	userID := "2"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/users/%s", groupID, appID, userID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Users.Delete(ctx, groupID, appID, userID)
+	if err != nil {
+		t.Fatalf("Users.Delete returned error: %v", err)
+	}
+}
+
+func TestUsers_actions(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	userID := "2"
+
+	basePath := fmt.Sprintf("/groups/%s/apps/%s/users/%s", groupID, appID, userID)
+
+	var calls []string
+	for _, action := range []string{"enable", "disable", "logout"} {
+		mux.HandleFunc(basePath+"/"+action, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodPut)
+			calls = append(calls, action)
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+
+	if _, err := client.Users.Enable(ctx, groupID, appID, userID); err != nil {
+		t.Fatalf("Users.Enable returned error: %v", err)
+	}
+	if _, err := client.Users.Disable(ctx, groupID, appID, userID); err != nil {
+		t.Fatalf("Users.Disable returned error: %v", err)
+	}
+	if _, err := client.Users.RevokeSessions(ctx, groupID, appID, userID); err != nil {
+		t.Fatalf("Users.RevokeSessions returned error: %v", err)
+	}
+
+	if diff := deep.Equal(calls, []string{"enable", "disable", "logout"}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestUsers_ListDevices(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	userID := "2"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/users/%s/devices", groupID, appID, userID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[{
+		  "device_id": "d1",
+		  "app_id": "com.example.app",
+		  "app_version": "1.0",
+		  "platform": "ios",
+		  "platform_version": "17.0",
+		  "sdk_version": "10.40.0"
+		}]`)
+	})
+
+	devices, _, err := client.Users.ListDevices(ctx, groupID, appID, userID)
+	if err != nil {
+		t.Fatalf("Users.ListDevices returned error: %v", err)
+	}
+
+	expected := []UserDevice{{
+		ID:              "d1",
+		AppID:           "com.example.app",
+		AppVersion:      "1.0",
+		Platform:        "ios",
+		PlatformVersion: "17.0",
+		SDKVersion:      "10.40.0",
+	}}
+
+	if diff := deep.Equal(devices, expected); diff != nil {
+		t.Error(diff)
+	}
+}
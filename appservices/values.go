@@ -0,0 +1,211 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	atlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	valuesBasePath = appsBasePath + "/%s/values"
+)
+
+// ValuesService provides access to the values related functions in the Realm API.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/values
+type ValuesService interface {
+	List(context.Context, string, string) ([]Value, *Response, error)
+	Get(context.Context, string, string, string) (*Value, *Response, error)
+	Create(context.Context, string, string, *Value) (*Value, *Response, error)
+	Update(context.Context, string, string, string, *Value) (*Response, error)
+	Delete(context.Context, string, string, string) (*Response, error)
+}
+
+// ValuesServiceOp provides an implementation of the ValuesService interface.
+type ValuesServiceOp service
+
+var _ ValuesService = &ValuesServiceOp{}
+
+// List all values. The content of each value is not returned.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/values
+func (s *ValuesServiceOp) List(ctx context.Context, groupID, appID string) ([]Value, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+
+	path := fmt.Sprintf(valuesBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var root []Value
+	resp, err := s.Client.Do(ctx, req, &root)
+
+	return root, resp, err
+}
+
+// Get retrieves a single value, including its content.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/values
+func (s *ValuesServiceOp) Get(ctx context.Context, groupID, appID, valueID string) (*Value, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if valueID == "" {
+		return nil, nil, atlas.NewArgError("valueID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(valuesBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, valueID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Value)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Create creates a value.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/values
+func (s *ValuesServiceOp) Create(ctx context.Context, groupID, appID string, createRequest *Value) (*Value, *Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, nil, atlas.NewArgError("appID", "must be set")
+	}
+	if createRequest == nil {
+		return nil, nil, atlas.NewArgError("createRequest", "cannot be nil")
+	}
+
+	path := fmt.Sprintf(valuesBasePath, groupID, appID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Value)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, err
+}
+
+// Update replaces a value.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/values
+func (s *ValuesServiceOp) Update(ctx context.Context, groupID, appID, valueID string, updateRequest *Value) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if valueID == "" {
+		return nil, atlas.NewArgError("valueID", "must be set")
+	}
+	if updateRequest == nil {
+		return nil, atlas.NewArgError("updateRequest", "cannot be nil")
+	}
+
+	basePath := fmt.Sprintf(valuesBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, valueID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, path, updateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Delete deletes a value.
+//
+// See more: https://www.mongodb.com/docs/atlas/app-services/admin/api/v3/#tag/values
+func (s *ValuesServiceOp) Delete(ctx context.Context, groupID, appID, valueID string) (*Response, error) {
+	if groupID == "" {
+		return nil, atlas.NewArgError("groupId", "must be set")
+	}
+	if appID == "" {
+		return nil, atlas.NewArgError("appID", "must be set")
+	}
+	if valueID == "" {
+		return nil, atlas.NewArgError("valueID", "must be set")
+	}
+
+	basePath := fmt.Sprintf(valuesBasePath, groupID, appID)
+	path := fmt.Sprintf("%s/%s", basePath, valueID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Value represents an App Services value. A value either holds plain JSON or,
+// when FromSecret is set, the name of the secret it exposes.
+type Value struct {
+	Value        interface{} `json:"value,omitempty"`
+	Private      *bool       `json:"private,omitempty"`
+	LastModified *int64      `json:"last_modified,omitempty"`
+	ID           string      `json:"_id,omitempty"`
+	Name         string      `json:"name,omitempty"`
+	FromSecret   bool        `json:"from_secret,omitempty"`
+}
+
+// NewPlainValue returns a value holding v, which must be JSON encodable.
+func NewPlainValue(name string, v interface{}) *Value {
+	return &Value{Name: name, Value: v}
+}
+
+// NewSecretValue returns a value exposing the secret named secretName.
+func NewSecretValue(name, secretName string) *Value {
+	return &Value{Name: name, Value: secretName, FromSecret: true}
+}
+
+// SecretName returns the name of the secret the value exposes, and false if it holds plain JSON.
+func (v *Value) SecretName() (string, bool) {
+	if !v.FromSecret {
+		return "", false
+	}
+	name, ok := v.Value.(string)
+	return name, ok
+}
@@ -0,0 +1,186 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestValues_List(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/values", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[
+		  {"_id": "1", "name": "tier", "private": false, "last_modified": 1615840000},
+		  {"_id": "2", "name": "apiKey", "private": true, "from_secret": true}
+		]`)
+	})
+
+	values, _, err := client.Values.List(ctx, groupID, appID)
+	if err != nil {
+		t.Fatalf("Values.List returned error: %v", err)
+	}
+
+	expected := []Value{
+		{ID: "1", Name: "tier", Private: pointer(false), LastModified: pointer[int64](1615840000)},
+		{ID: "2", Name: "apiKey", Private: pointer(true), FromSecret: true},
+	}
+
+	if diff := deep.Equal(values, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestValues_Get(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	valueID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/values/%s", groupID, appID, valueID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"_id": "1", "name": "limits", "value": {"max": 10, "tiers": ["a", "b"]}}`)
+	})
+
+	value, _, err := client.Values.Get(ctx, groupID, appID, valueID)
+	if err != nil {
+		t.Fatalf("Values.Get returned error: %v", err)
+	}
+
+	expected := &Value{
+		ID:    "1",
+		Name:  "limits",
+		Value: map[string]interface{}{"max": 10.0, "tiers": []interface{}{"a", "b"}},
+	}
+
+	if diff := deep.Equal(value, expected); diff != nil {
+		t.Error(diff)
+	}
+	if _, ok := value.SecretName(); ok {
+		t.Error("SecretName() reported a secret for a plain value")
+	}
+}
+
+func TestValues_Create(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/values", groupID, appID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		expected := map[string]interface{}{
+			"name":        "apiKey",
+			"value":       "apiKeySecret",
+			"from_secret": true,
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+
+		fmt.Fprint(w, `{"_id": "2", "name": "apiKey", "value": "apiKeySecret", "from_secret": true}`)
+	})
+
+	value, _, err := client.Values.Create(ctx, groupID, appID, NewSecretValue("apiKey", "apiKeySecret"))
+	if err != nil {
+		t.Fatalf("Values.Create returned error: %v", err)
+	}
+
+	if name, ok := value.SecretName(); !ok || name != "apiKeySecret" {
+		t.Errorf("SecretName() = %v, %v, expected apiKeySecret, true", name, ok)
+	}
+}
+
+func TestValues_Update(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	valueID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/values/%s", groupID, appID, valueID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		expected := map[string]interface{}{
+			"name":  "enabled",
+			"value": false,
+		}
+
+		var v map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&v)
+		if err != nil {
+			t.Fatalf("Decode json: %v", err)
+		}
+
+		if diff := deep.Equal(v, expected); diff != nil {
+			t.Error(diff)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Values.Update(ctx, groupID, appID, valueID, NewPlainValue("enabled", false))
+	if err != nil {
+		t.Fatalf("Values.Update returned error: %v", err)
+	}
+}
+
+func TestValues_Delete(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	groupID := "6c7498dg87d9e6526801572b"
+	appID := "5c7498dg87d9e6526801572b"
+	valueID := "1"
+
+	path := fmt.Sprintf("/groups/%s/apps/%s/values/%s", groupID, appID, valueID)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Values.Delete(ctx, groupID, appID, valueID)
+	if err != nil {
+		t.Fatalf("Values.Delete returned error: %v", err)
+	}
+}
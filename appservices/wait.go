@@ -0,0 +1,68 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservices
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	defaultWaitMinDelay = time.Second
+	defaultWaitMaxDelay = 30 * time.Second
+)
+
+// WaitOptions configures how the Wait functions poll the API.
+type WaitOptions struct {
+	// MinDelay is the delay before the second poll, doubled after every poll. Defaults to 1s.
+	MinDelay time.Duration
+	// MaxDelay caps the delay between polls. Defaults to 30s.
+	MaxDelay time.Duration
+}
+
+// waitFor calls poll until it reports done or fails, sleeping between calls
+// with exponential backoff. It returns ctx.Err() as soon as ctx is done.
+func waitFor[T any](ctx context.Context, opts *WaitOptions, poll func(context.Context) (T, bool, error)) (T, error) {
+	minDelay, maxDelay := defaultWaitMinDelay, defaultWaitMaxDelay
+	if opts != nil {
+		if opts.MinDelay > 0 {
+			minDelay = opts.MinDelay
+		}
+		if opts.MaxDelay > 0 {
+			maxDelay = opts.MaxDelay
+		}
+	}
+
+	delay := minDelay
+	for {
+		v, done, err := poll(ctx)
+		if done || err != nil {
+			return v, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return v, ctx.Err()
+		case <-timer.C:
+		}
+
+		delay *= 2
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+}
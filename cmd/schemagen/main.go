@@ -0,0 +1,95 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command schemagen generates Go structs from an App Services collection schema.
+//
+// It reads either a bare JSON schema, such as the schema.json file of an
+// exported app, or a schema document returned by SchemasService.Get, and is
+// meant to be run from a go:generate directive:
+//
+//	//go:generate go run github.com/mongodb-labs/go-client-mongodb-atlas-app-services/cmd/schemagen -in data_sources/mongodb-atlas/store/orders/schema.json -type Order -out order_gen.go
+//
+// The package name defaults to the package of the file holding the directive.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appservices"
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/schemagen"
+)
+
+func main() {
+	in := flag.String("in", "-", "schema file to read, - for standard input")
+	out := flag.String("out", "-", "Go file to write, - for standard output")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "name of the generated package")
+	typeName := flag.String("type", "", "name of the root struct, defaults to the schema title")
+	flag.Parse()
+
+	if err := run(*in, *out, &schemagen.Options{Package: *pkg, TypeName: *typeName}); err != nil {
+		fmt.Fprintln(os.Stderr, "schemagen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(in, out string, opts *schemagen.Options) error {
+	var data []byte
+	var err error
+	if in == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(in)
+	}
+	if err != nil {
+		return err
+	}
+
+	schema, err := decodeSchema(data)
+	if err != nil {
+		return fmt.Errorf("decoding %s: %w", in, err)
+	}
+
+	var buf bytes.Buffer
+	if err := schemagen.Generate(&buf, schema, opts); err != nil {
+		return err
+	}
+
+	if out == "-" {
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(out, buf.Bytes(), 0o644)
+}
+
+// decodeSchema decodes a bare JSON schema or the schema of a schema document.
+func decodeSchema(data []byte) (*appservices.JSONSchema, error) {
+	var doc appservices.Schema
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Schema != nil {
+		return doc.Schema, nil
+	}
+
+	schema := new(appservices.JSONSchema)
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
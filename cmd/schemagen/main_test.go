@@ -0,0 +1,78 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/schemagen"
+)
+
+const bareSchema = `{
+  "title": "customer",
+  "bsonType": "object",
+  "required": ["_id"],
+  "properties": {
+    "_id": {"bsonType": "objectId"},
+    "name": {"bsonType": "string"}
+  }
+}`
+
+const expectedSource = "// Code generated by schemagen. DO NOT EDIT.\n\npackage store\n\n" +
+	"import (\n\t\"go.mongodb.org/mongo-driver/bson/primitive\"\n)\n\n" +
+	"type Customer struct {\n" +
+	"\tID   primitive.ObjectID `json:\"_id\" bson:\"_id\"`\n" +
+	"\tName *string            `json:\"name,omitempty\" bson:\"name,omitempty\"`\n" +
+	"}\n"
+
+func TestRun(t *testing.T) {
+	for name, input := range map[string]string{
+		"bare schema":     bareSchema,
+		"schema document": `{"_id": "1", "metadata": {"database": "store", "collection": "customers"}, "schema": ` + bareSchema + `}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			in := filepath.Join(dir, "schema.json")
+			out := filepath.Join(dir, "customer_gen.go")
+			if err := os.WriteFile(in, []byte(input), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := run(in, out, &schemagen.Options{Package: "store"}); err != nil {
+				t.Fatalf("run returned error: %v", err)
+			}
+
+			got, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != expectedSource {
+				t.Errorf("run wrote:\n%s\nexpected:\n%s", got, expectedSource)
+			}
+		})
+	}
+}
+
+func TestRun_invalidSchema(t *testing.T) {
+	in := filepath.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(in, []byte(`{"bsonType": 1}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := run(in, filepath.Join(t.TempDir(), "out.go"), &schemagen.Options{TypeName: "T"}); err == nil {
+		t.Error("expected an error for an invalid schema")
+	}
+}
@@ -0,0 +1,412 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package depsarchive builds the node_modules archive uploaded with
+// DependenciesService.Upload from a local npm project directory.
+//
+// Archives are reproducible: entries are sorted and their timestamps and
+// ownership are fixed, so building the same node_modules twice yields the same
+// digest and an upload can be skipped when the digest did not change.
+package depsarchive // import "github.com/mongodb-labs/go-client-mongodb-atlas-app-services/depsarchive"
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// FileName is the name the archive should be uploaded with.
+	FileName = "node_modules.tar.gz"
+
+	nodeModules     = "node_modules"
+	packageJSONFile = "package.json"
+)
+
+// Options configures Build.
+type Options struct {
+	// ModTime is the modification time of every archived entry. Defaults to the Unix epoch.
+	ModTime time.Time
+	// IncludeDev also archives the devDependencies of the root package.json.
+	IncludeDev bool
+}
+
+// Result describes a built archive.
+type Result struct {
+	// Digest is the SHA-256 of the compressed archive, as "sha256:<hex>".
+	Digest string
+	// Packages lists the archived packages by their path relative to the project directory.
+	Packages []string
+	// Size is the size in bytes of the compressed archive.
+	Size int64
+}
+
+type packageJSON struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Dependencies         map[string]string `json:"dependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+}
+
+// Build writes to w a gzipped tar of the node_modules directory of the npm
+// project in dir, restricted to the packages reachable from the dependencies
+// of its package.json. Packages only reachable from devDependencies are left
+// out unless opts.IncludeDev is set.
+func Build(dir string, w io.Writer, opts *Options) (*Result, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	modTime := opts.ModTime
+	if modTime.IsZero() {
+		modTime = time.Unix(0, 0)
+	}
+
+	packages, err := resolve(dir, opts.IncludeDev)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := collect(dir, root, packages)
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha256.New()
+	cw := &countingWriter{w: io.MultiWriter(w, h)}
+	if err := write(cw, entries, modTime); err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Digest:   "sha256:" + hex.EncodeToString(h.Sum(nil)),
+		Packages: packages,
+		Size:     cw.n,
+	}, nil
+}
+
+// resolve returns the slash separated paths, relative to dir, of every package
+// reachable from the root package.json, following the Node.js module resolution.
+func resolve(dir string, includeDev bool) ([]string, error) {
+	root, err := readPackageJSON(filepath.Join(dir, packageJSONFile))
+	if err != nil {
+		return nil, err
+	}
+
+	type pending struct {
+		from     string
+		name     string
+		optional bool
+	}
+
+	var queue []pending
+	enqueue := func(from string, deps map[string]string, optional bool) {
+		names := make([]string, 0, len(deps))
+		for name := range deps {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			queue = append(queue, pending{from: from, name: name, optional: optional})
+		}
+	}
+
+	enqueue("", root.Dependencies, false)
+	enqueue("", root.OptionalDependencies, true)
+	if includeDev {
+		enqueue("", root.DevDependencies, false)
+	}
+
+	seen := map[string]bool{}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+
+		pkgPath, ok := lookup(dir, p.from, p.name)
+		if !ok {
+			if p.optional {
+				continue
+			}
+			return nil, fmt.Errorf("depsarchive: package %q required by %q is not installed", p.name, displayName(p.from))
+		}
+		if seen[pkgPath] {
+			continue
+		}
+		seen[pkgPath] = true
+
+		pkg, err := readPackageJSON(filepath.Join(dir, filepath.FromSlash(pkgPath), packageJSONFile))
+		if err != nil {
+			return nil, err
+		}
+		enqueue(pkgPath, pkg.Dependencies, false)
+		enqueue(pkgPath, pkg.OptionalDependencies, true)
+	}
+
+	packages := make([]string, 0, len(seen))
+	for p := range seen {
+		packages = append(packages, p)
+	}
+	sort.Strings(packages)
+	return packages, nil
+}
+
+// lookup finds name in the node_modules directory of from or of any of its ancestors.
+func lookup(dir, from, name string) (string, bool) {
+	for {
+		candidate := path.Join(from, nodeModules, name)
+		if info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(candidate))); err == nil && info.IsDir() {
+			return candidate, true
+		}
+		if from == "" {
+			return "", false
+		}
+		// Step out of ".../node_modules/<name>" or ".../node_modules/@scope/<name>".
+		parent := path.Dir(from)
+		if path.Base(parent) != nodeModules {
+			parent = path.Dir(parent)
+		}
+		from = path.Dir(parent)
+		if from == "." {
+			from = ""
+		}
+	}
+}
+
+// entry is a directory or file to archive.
+type entry struct {
+	// name is the slash separated path of the entry in the archive.
+	name string
+	// src is the path of the archived file, empty for the directories holding packages.
+	src string
+	// link is the target of a symlink, relative to the directory of name.
+	link string
+}
+
+// collect returns the directories and files to archive, sorted by name.
+// Nested node_modules directories are skipped, the packages they hold are
+// archived on their own if they are reachable. A package that is a symlink, as
+// installed by pnpm, npm workspaces or npm link, is archived with the contents
+// of its target under the package path; its target must be inside root, the
+// resolved dir.
+func collect(dir, root string, packages []string) ([]entry, error) {
+	set := map[string]entry{}
+	addParents := func(p string) {
+		for p = path.Dir(p); p != "."; p = path.Dir(p) {
+			if _, ok := set[p]; ok {
+				return
+			}
+			set[p] = entry{name: p}
+		}
+	}
+
+	for _, pkg := range packages {
+		src, err := filepath.EvalSymlinks(filepath.Join(dir, filepath.FromSlash(pkg)))
+		if err != nil {
+			return nil, err
+		}
+		if !within(root, src) {
+			return nil, fmt.Errorf("depsarchive: package %q links outside %s", pkg, dir)
+		}
+		err = filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() && d.Name() == nodeModules && p != src {
+				return filepath.SkipDir
+			}
+			rel, err := filepath.Rel(src, p)
+			if err != nil {
+				return err
+			}
+			e := entry{name: path.Join(pkg, filepath.ToSlash(rel)), src: p}
+			if d.Type()&fs.ModeSymlink != 0 {
+				if e.link, err = linkTarget(root, src, &e); err != nil {
+					return err
+				}
+			}
+			set[e.name] = e
+			addParents(e.name)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entries := make([]entry, 0, len(set))
+	for _, e := range set {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+	return entries, nil
+}
+
+// within reports whether p is root or inside it.
+func within(root, p string) bool {
+	rel, err := filepath.Rel(root, p)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func write(w io.Writer, entries []entry, modTime time.Time) error {
+	gw, err := gzip.NewWriterLevel(w, gzip.BestCompression)
+	if err != nil {
+		return err
+	}
+	tw := tar.NewWriter(gw)
+
+	for i := range entries {
+		if err := writeEntry(tw, &entries[i], modTime); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func writeEntry(tw *tar.Writer, e *entry, modTime time.Time) error {
+	hdr := &tar.Header{
+		Name:     e.name + "/",
+		Typeflag: tar.TypeDir,
+		Mode:     0o755,
+		ModTime:  modTime,
+		Format:   tar.FormatPAX,
+	}
+	if e.src == "" {
+		return tw.WriteHeader(hdr)
+	}
+
+	info, err := os.Lstat(e.src)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case info.IsDir():
+	case info.Mode()&fs.ModeSymlink != 0:
+		hdr.Name = e.name
+		hdr.Typeflag = tar.TypeSymlink
+		hdr.Linkname = e.link
+		hdr.Mode = 0o777
+	case info.Mode().IsRegular():
+		hdr.Name = e.name
+		hdr.Typeflag = tar.TypeReg
+		hdr.Size = info.Size()
+		hdr.Mode = 0o644
+		if info.Mode()&0o111 != 0 {
+			hdr.Mode = 0o755
+		}
+	default:
+		return nil
+	}
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if hdr.Typeflag != tar.TypeReg {
+		return nil
+	}
+
+	f, err := os.Open(e.src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// linkTarget returns the target of the symlink e of the package whose
+// contents are in src. Absolute targets and targets outside root are
+// rejected, so that the archive does not refer to local paths.
+func linkTarget(root, src string, e *entry) (string, error) {
+	target, err := os.Readlink(e.src)
+	if err != nil {
+		return "", err
+	}
+	if filepath.IsAbs(target) {
+		return "", fmt.Errorf("depsarchive: %s links to the absolute path %s", e.name, target)
+	}
+	resolved := filepath.Join(filepath.Dir(e.src), target)
+	if !within(root, resolved) {
+		return "", fmt.Errorf("depsarchive: %s links outside the project directory", e.name)
+	}
+	if within(src, resolved) {
+		// The package is archived as a whole, wherever its contents are.
+		return filepath.ToSlash(target), nil
+	}
+	// Point to the target at its place in the project, from the place of e in the archive.
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil {
+		return "", err
+	}
+	linkname, err := filepath.Rel(filepath.Dir(filepath.FromSlash(e.name)), rel)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(linkname), nil
+}
+
+func readPackageJSON(p string) (*packageJSON, error) {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("depsarchive: %s not found", p)
+		}
+		return nil, err
+	}
+	pkg := new(packageJSON)
+	if err := json.Unmarshal(b, pkg); err != nil {
+		return nil, fmt.Errorf("depsarchive: %s: %w", p, err)
+	}
+	return pkg, nil
+}
+
+func displayName(from string) string {
+	if from == "" {
+		return packageJSONFile
+	}
+	return from
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
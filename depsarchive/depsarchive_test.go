@@ -0,0 +1,283 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depsarchive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+// writeProject lays out an npm project where "a" depends on "b" and on a
+// nested copy of "c", "@scope/e" is optional and "d" is a dev dependency.
+func writeProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"package.json": `{
+			"name": "app",
+			"dependencies": {"a": "1.0.0", "@scope/e": "1.0.0"},
+			"optionalDependencies": {"missing": "1.0.0"},
+			"devDependencies": {"d": "1.0.0"}
+		}`,
+		"node_modules/a/package.json":                `{"name": "a", "dependencies": {"b": "1.0.0", "c": "2.0.0"}}`,
+		"node_modules/a/index.js":                    "module.exports = 'a';\n",
+		"node_modules/a/node_modules/c/package.json": `{"name": "c", "version": "2.0.0"}`,
+		"node_modules/b/package.json":                `{"name": "b"}`,
+		"node_modules/c/package.json":                `{"name": "c", "version": "1.0.0"}`,
+		"node_modules/d/package.json":                `{"name": "d"}`,
+		"node_modules/@scope/e/package.json":         `{"name": "@scope/e", "dependencies": {"b": "1.0.0"}}`,
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func readEntries(t *testing.T, b []byte) []string {
+	t.Helper()
+	gr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gr)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return names
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !hdr.ModTime.Equal(time.Unix(0, 0)) {
+			t.Errorf("%s ModTime = %v, expected the Unix epoch", hdr.Name, hdr.ModTime)
+		}
+		names = append(names, hdr.Name)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	dir := writeProject(t)
+
+	var buf bytes.Buffer
+	res, err := Build(dir, &buf, nil)
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+
+	expectedPackages := []string{
+		"node_modules/@scope/e",
+		"node_modules/a",
+		"node_modules/a/node_modules/c",
+		"node_modules/b",
+	}
+	if diff := deep.Equal(res.Packages, expectedPackages); diff != nil {
+		t.Error(diff)
+	}
+
+	expectedEntries := []string{
+		"node_modules/",
+		"node_modules/@scope/",
+		"node_modules/@scope/e/",
+		"node_modules/@scope/e/package.json",
+		"node_modules/a/",
+		"node_modules/a/index.js",
+		"node_modules/a/node_modules/",
+		"node_modules/a/node_modules/c/",
+		"node_modules/a/node_modules/c/package.json",
+		"node_modules/a/package.json",
+		"node_modules/b/",
+		"node_modules/b/package.json",
+	}
+	if diff := deep.Equal(readEntries(t, buf.Bytes()), expectedEntries); diff != nil {
+		t.Error(diff)
+	}
+	if res.Size != int64(buf.Len()) {
+		t.Errorf("Size = %d, expected %d", res.Size, buf.Len())
+	}
+}
+
+func TestBuild_reproducible(t *testing.T) {
+	dir := writeProject(t)
+
+	first, err := Build(dir, io.Discard, nil)
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+
+	// Touching files must not change the digest.
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "node_modules", "a", "index.js"), later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := Build(dir, io.Discard, nil)
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	if first.Digest != second.Digest {
+		t.Errorf("Digest changed from %v to %v", first.Digest, second.Digest)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "node_modules", "a", "index.js"), []byte("changed"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	third, err := Build(dir, io.Discard, nil)
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	if first.Digest == third.Digest {
+		t.Errorf("Digest did not change after a file changed")
+	}
+}
+
+func TestBuild_includeDev(t *testing.T) {
+	dir := writeProject(t)
+
+	res, err := Build(dir, io.Discard, &Options{IncludeDev: true})
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+
+	found := false
+	for _, p := range res.Packages {
+		found = found || p == "node_modules/d"
+	}
+	if !found {
+		t.Errorf("Packages = %v, expected node_modules/d to be included", res.Packages)
+	}
+}
+
+func TestBuild_missingDependency(t *testing.T) {
+	dir := writeProject(t)
+	if err := os.RemoveAll(filepath.Join(dir, "node_modules", "b")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Build(dir, io.Discard, nil); err == nil {
+		t.Error("expected an error for a missing dependency")
+	}
+}
+
+func TestBuild_symlinkedPackage(t *testing.T) {
+	dir := writeProject(t)
+	// Lay out "b" as pnpm does, in its store with a symlink to it.
+	store := filepath.Join(dir, "node_modules", ".pnpm", "b@1.0.0", "node_modules", "b")
+	if err := os.MkdirAll(filepath.Dir(store), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(filepath.Join(dir, "node_modules", "b"), store); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(store, "index.js"), []byte("module.exports = 'b';\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("index.js", filepath.Join(store, "main.js")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(".pnpm", "b@1.0.0", "node_modules", "b"), filepath.Join(dir, "node_modules", "b")); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := Build(dir, &buf, nil); err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+
+	gr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gr)
+	found := map[string]*tar.Header{}
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		found[hdr.Name] = hdr
+	}
+
+	expected := map[string]struct {
+		typeflag byte
+		linkname string
+	}{
+		"node_modules/b/":             {typeflag: tar.TypeDir},
+		"node_modules/b/index.js":     {typeflag: tar.TypeReg},
+		"node_modules/b/main.js":      {typeflag: tar.TypeSymlink, linkname: "index.js"},
+		"node_modules/b/package.json": {typeflag: tar.TypeReg},
+	}
+	for name, want := range expected {
+		hdr, ok := found[name]
+		if !ok {
+			t.Errorf("%s is not archived", name)
+			continue
+		}
+		if hdr.Typeflag != want.typeflag || hdr.Linkname != want.linkname {
+			t.Errorf("%s has type %q and link %q, expected %q and %q", name, hdr.Typeflag, hdr.Linkname, want.typeflag, want.linkname)
+		}
+	}
+	for name := range found {
+		if strings.Contains(name, ".pnpm") {
+			t.Errorf("%s is archived, expected only the packages", name)
+		}
+	}
+}
+
+func TestBuild_symlinkOutsideProject(t *testing.T) {
+	outside := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outside, "package.json"), []byte(`{"name": "b"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := writeProject(t)
+	b := filepath.Join(dir, "node_modules", "b")
+	if err := os.RemoveAll(b); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, b); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Build(dir, io.Discard, nil); err == nil {
+		t.Error("expected an error for a package linked outside the project")
+	}
+
+	dir = writeProject(t)
+	if err := os.Symlink(filepath.Join(outside, "package.json"), filepath.Join(dir, "node_modules", "b", "link.json")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Build(dir, io.Discard, nil); err == nil {
+		t.Error("expected an error for a file linked to an absolute path")
+	}
+}
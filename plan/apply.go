@@ -0,0 +1,164 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appservices"
+)
+
+// Deployer batches the changes made through the service clients into a single deployment.
+type Deployer interface {
+	// CreateDraft opens a draft that collects every subsequent change and returns its ID.
+	CreateDraft(ctx context.Context, groupID, appID string) (string, error)
+	// DeployDraft deploys a draft and returns the ID of the resulting deployment.
+	DeployDraft(ctx context.Context, groupID, appID, draftID string) (string, error)
+	// DiscardDraft drops a draft along with every change it collected.
+	DiscardDraft(ctx context.Context, groupID, appID, draftID string) error
+	// WaitForDeployment blocks until a deployment finishes and returns an error if it failed.
+	WaitForDeployment(ctx context.Context, groupID, appID, deploymentID string) error
+}
+
+// Applier executes plans against a deployed app.
+type Applier struct {
+	Apps          appservices.AppsService
+	EventTriggers appservices.EventTriggersService
+	Deployer      Deployer
+}
+
+// NewApplier returns an Applier that uses the services of client. A nil
+// deployer defaults to NewDeployer(client, nil).
+func NewApplier(client *appservices.Client, deployer Deployer) *Applier {
+	if deployer == nil {
+		deployer = NewDeployer(client, nil)
+	}
+	return &Applier{
+		Apps:          client.Apps,
+		EventTriggers: client.EventTriggers,
+		Deployer:      deployer,
+	}
+}
+
+// Apply executes every change of p inside a single draft, deploys it and waits
+// for the deployment to finish. If any change fails, the draft is discarded so
+// the deployed app is left untouched, and an *ApplyError naming every failed
+// resource is returned.
+func (a *Applier) Apply(ctx context.Context, groupID, appID string, p *Plan) error {
+	if p == nil || p.Empty() {
+		return nil
+	}
+
+	draftID, err := a.Deployer.CreateDraft(ctx, groupID, appID)
+	if err != nil {
+		return fmt.Errorf("plan: create draft: %w", err)
+	}
+
+	var errs []error
+	for i := range p.Changes {
+		c := &p.Changes[i]
+		if err := a.apply(ctx, groupID, appID, c); err != nil {
+			errs = append(errs, &ResourceError{Change: c, Err: err})
+		}
+	}
+
+	if len(errs) == 0 {
+		deploymentID, err := a.Deployer.DeployDraft(ctx, groupID, appID, draftID)
+		if err == nil {
+			if err := a.Deployer.WaitForDeployment(ctx, groupID, appID, deploymentID); err != nil {
+				return &ApplyError{Errors: []error{fmt.Errorf("deployment %s: %w", deploymentID, err)}}
+			}
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("deploy draft %s: %w", draftID, err))
+	}
+
+	// Discard even when ctx is done, since an app only allows one draft at a time.
+	if err := a.Deployer.DiscardDraft(context.WithoutCancel(ctx), groupID, appID, draftID); err != nil {
+		errs = append(errs, fmt.Errorf("discard draft %s: %w", draftID, err))
+	}
+	return &ApplyError{Errors: errs}
+}
+
+func (a *Applier) apply(ctx context.Context, groupID, appID string, c *Change) error {
+	var err error
+	switch c.Resource {
+	case AppResource:
+		if c.Action != Update {
+			return fmt.Errorf("unsupported action %q", c.Action)
+		}
+		_, _, err = a.Apps.Update(ctx, groupID, appID, c.App)
+	case TriggerResource:
+		switch c.Action {
+		case Create:
+			_, _, err = a.EventTriggers.Create(ctx, groupID, appID, c.Trigger)
+		case Update:
+			_, _, err = a.EventTriggers.Update(ctx, groupID, appID, c.ID, c.Trigger)
+		case Delete:
+			_, err = a.EventTriggers.Delete(ctx, groupID, appID, c.ID)
+		default:
+			return fmt.Errorf("unsupported action %q", c.Action)
+		}
+	default:
+		return fmt.Errorf("unsupported resource %q", c.Resource)
+	}
+	return err
+}
+
+// ResourceError reports the failure of a single change.
+type ResourceError struct {
+	Change *Change
+	Err    error
+}
+
+func (e *ResourceError) Error() string {
+	return fmt.Sprintf("%s %s %q: %v", e.Change.Action, e.Change.Resource, e.Change.Name, e.Err)
+}
+
+func (e *ResourceError) Unwrap() error {
+	return e.Err
+}
+
+// ApplyError collects every error of a failed Apply.
+type ApplyError struct {
+	Errors []error
+}
+
+func (e *ApplyError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("plan: apply failed with %d error(s): %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+func (e *ApplyError) Unwrap() []error {
+	return e.Errors
+}
+
+// FailedResources returns the changes that could not be applied.
+func (e *ApplyError) FailedResources() []*Change {
+	var changes []*Change
+	for _, err := range e.Errors {
+		var re *ResourceError
+		if errors.As(err, &re) {
+			changes = append(changes, re.Change)
+		}
+	}
+	return changes
+}
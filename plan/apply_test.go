@@ -0,0 +1,207 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appservices"
+)
+
+type fakeDeployer struct {
+	calls     []string
+	deployErr error
+	waitErr   error
+}
+
+func (d *fakeDeployer) CreateDraft(_ context.Context, _, _ string) (string, error) {
+	d.calls = append(d.calls, "create")
+	return "draft", nil
+}
+
+func (d *fakeDeployer) DeployDraft(_ context.Context, _, _, draftID string) (string, error) {
+	d.calls = append(d.calls, "deploy "+draftID)
+	return "deployment", d.deployErr
+}
+
+func (d *fakeDeployer) DiscardDraft(ctx context.Context, _, _, draftID string) error {
+	d.calls = append(d.calls, "discard "+draftID)
+	return ctx.Err()
+}
+
+func (d *fakeDeployer) WaitForDeployment(_ context.Context, _, _, deploymentID string) error {
+	d.calls = append(d.calls, "wait "+deploymentID)
+	return d.waitErr
+}
+
+// setupApplier returns an Applier talking to a test server that rejects any
+// trigger named "bad" and records the requests it receives.
+func setupApplier(t *testing.T, d Deployer) (applier *Applier, requests *[]string, teardown func()) {
+	t.Helper()
+	var reqs []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Name string `json:"name"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		reqs = append(reqs, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+		if body.Name == "bad" || r.URL.Path == fmt.Sprintf("/groups/%s/apps/%s/triggers/bad-id", groupID, appID) {
+			http.Error(w, `{"error": "invalid trigger"}`, http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	})
+	server := httptest.NewServer(mux)
+
+	client, err := appservices.New(nil, appservices.SetBaseURL(server.URL+"/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewApplier(client, d), &reqs, server.Close
+}
+
+func testPlan(failing ...string) *Plan {
+	p := &Plan{Changes: []Change{
+		{Action: Create, Resource: TriggerResource, Name: "a", Trigger: &appservices.EventTriggerRequest{Name: "a"}},
+		{Action: Update, Resource: TriggerResource, Name: "b", ID: "b-id", Trigger: &appservices.EventTriggerRequest{Name: "b"}},
+		{Action: Delete, Resource: TriggerResource, Name: "c", ID: "c-id"},
+	}}
+	for _, name := range failing {
+		p.Changes = append(p.Changes, Change{Action: Create, Resource: TriggerResource, Name: name, Trigger: &appservices.EventTriggerRequest{Name: name}})
+	}
+	return p
+}
+
+func TestApplier_Apply(t *testing.T) {
+	d := &fakeDeployer{}
+	applier, requests, teardown := setupApplier(t, d)
+	defer teardown()
+
+	if err := applier.Apply(context.Background(), groupID, appID, testPlan()); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+
+	triggersPath := fmt.Sprintf("/groups/%s/apps/%s/triggers", groupID, appID)
+	expectedRequests := []string{
+		"POST " + triggersPath,
+		"PUT " + triggersPath + "/b-id",
+		"DELETE " + triggersPath + "/c-id",
+	}
+	if diff := deep.Equal(*requests, expectedRequests); diff != nil {
+		t.Error(diff)
+	}
+	if diff := deep.Equal(d.calls, []string{"create", "deploy draft", "wait deployment"}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestApplier_Apply_changeFailure(t *testing.T) {
+	d := &fakeDeployer{}
+	applier, _, teardown := setupApplier(t, d)
+	defer teardown()
+
+	p := testPlan("bad")
+	p.Changes = append(p.Changes, Change{Action: Delete, Resource: TriggerResource, Name: "bad-delete", ID: "bad-id"})
+
+	err := applier.Apply(context.Background(), groupID, appID, p)
+
+	var applyErr *ApplyError
+	if !errors.As(err, &applyErr) {
+		t.Fatalf("Apply returned %v, expected an *ApplyError", err)
+	}
+	var names []string
+	for _, c := range applyErr.FailedResources() {
+		names = append(names, c.Name)
+	}
+	if diff := deep.Equal(names, []string{"bad", "bad-delete"}); diff != nil {
+		t.Error(diff)
+	}
+	var errResp *appservices.ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Errorf("expected the API error to be wrapped, got %v", err)
+	}
+	if diff := deep.Equal(d.calls, []string{"create", "discard draft"}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestApplier_Apply_deployFailure(t *testing.T) {
+	d := &fakeDeployer{deployErr: errors.New("conflict")}
+	applier, _, teardown := setupApplier(t, d)
+	defer teardown()
+
+	err := applier.Apply(context.Background(), groupID, appID, testPlan())
+	if !errors.Is(err, d.deployErr) {
+		t.Fatalf("Apply returned %v, expected %v", err, d.deployErr)
+	}
+	if diff := deep.Equal(d.calls, []string{"create", "deploy draft", "discard draft"}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestApplier_Apply_canceled(t *testing.T) {
+	d := &fakeDeployer{}
+	applier, _, teardown := setupApplier(t, d)
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := applier.Apply(ctx, groupID, appID, testPlan())
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Apply returned %v, expected %v", err, context.Canceled)
+	}
+	var applyErr *ApplyError
+	if !errors.As(err, &applyErr) || len(applyErr.FailedResources()) != len(applyErr.Errors) {
+		t.Errorf("expected only the changes to fail, got %v", err)
+	}
+	if diff := deep.Equal(d.calls, []string{"create", "discard draft"}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestNewApplier_defaultDeployer(t *testing.T) {
+	client, err := appservices.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applier := NewApplier(client, nil); applier.Deployer == nil {
+		t.Error("expected a default Deployer")
+	}
+}
+
+func TestApplier_Apply_emptyPlan(t *testing.T) {
+	d := &fakeDeployer{}
+	applier, _, teardown := setupApplier(t, d)
+	defer teardown()
+
+	if err := applier.Apply(context.Background(), groupID, appID, &Plan{}); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	if len(d.calls) != 0 {
+		t.Errorf("expected no draft for an empty plan, got %v", d.calls)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"context"
+
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appservices"
+)
+
+// clientDeployer implements Deployer with the drafts and deployments services.
+type clientDeployer struct {
+	drafts      appservices.DraftsService
+	deployments appservices.DeploymentsService
+	wait        *appservices.WaitOptions
+}
+
+// NewDeployer returns a Deployer that uses the drafts and deployments services
+// of client, polling deployments as configured by opts.
+func NewDeployer(client *appservices.Client, opts *appservices.WaitOptions) Deployer {
+	return &clientDeployer{
+		drafts:      client.Drafts,
+		deployments: client.Deployments,
+		wait:        opts,
+	}
+}
+
+func (d *clientDeployer) CreateDraft(ctx context.Context, groupID, appID string) (string, error) {
+	draft, _, err := d.drafts.Create(ctx, groupID, appID)
+	if err != nil {
+		return "", err
+	}
+	return draft.ID, nil
+}
+
+func (d *clientDeployer) DeployDraft(ctx context.Context, groupID, appID, draftID string) (string, error) {
+	deployment, _, err := d.drafts.Deploy(ctx, groupID, appID, draftID)
+	if err != nil {
+		return "", err
+	}
+	return deployment.ID, nil
+}
+
+func (d *clientDeployer) DiscardDraft(ctx context.Context, groupID, appID, draftID string) error {
+	_, err := d.drafts.Delete(ctx, groupID, appID, draftID)
+	return err
+}
+
+func (d *clientDeployer) WaitForDeployment(ctx context.Context, groupID, appID, deploymentID string) error {
+	_, err := appservices.WaitForDeployment(ctx, d.deployments, groupID, appID, deploymentID, d.wait)
+	return err
+}
@@ -0,0 +1,62 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appservices"
+)
+
+func TestNewDeployer(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	appPath := fmt.Sprintf("/groups/%s/apps/%s", groupID, appID)
+	mux.HandleFunc(appPath+"/drafts", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"_id": "draft"}`)
+	})
+	mux.HandleFunc(appPath+"/drafts/draft/deployment", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"_id": "deployment", "status": "created"}`)
+	})
+	mux.HandleFunc(appPath+"/deployments/deployment", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"_id": "deployment", "status": "successful"}`)
+	})
+
+	client, err := appservices.New(nil, appservices.SetBaseURL(server.URL+"/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := NewDeployer(client, &appservices.WaitOptions{MinDelay: time.Millisecond})
+	ctx := context.Background()
+
+	draftID, err := d.CreateDraft(ctx, groupID, appID)
+	if err != nil || draftID != "draft" {
+		t.Fatalf("CreateDraft returned %q, %v", draftID, err)
+	}
+	deploymentID, err := d.DeployDraft(ctx, groupID, appID, draftID)
+	if err != nil || deploymentID != "deployment" {
+		t.Fatalf("DeployDraft returned %q, %v", deploymentID, err)
+	}
+	if err := d.WaitForDeployment(ctx, groupID, appID, deploymentID); err != nil {
+		t.Fatalf("WaitForDeployment returned %v", err)
+	}
+}
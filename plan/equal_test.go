@@ -0,0 +1,83 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestDiffDocuments(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     interface{}
+		expected []string
+	}{
+		{
+			name: "key order and number formatting",
+			a:    map[string]interface{}{"x": 1, "y": 2.50},
+			b:    map[string]interface{}{"y": 2.5, "x": 1.0},
+		},
+		{
+			name: "empty values",
+			a:    map[string]interface{}{"x": map[string]interface{}{}},
+			b:    map[string]interface{}{"y": nil, "z": []interface{}{}},
+		},
+		{
+			name:     "nested change",
+			a:        map[string]interface{}{"config": map[string]interface{}{"match": map[string]interface{}{"a": 1}}},
+			b:        map[string]interface{}{"config": map[string]interface{}{"match": map[string]interface{}{"a": 2}}},
+			expected: []string{"config.match.a"},
+		},
+		{
+			name:     "array order matters",
+			a:        map[string]interface{}{"ops": []interface{}{"INSERT", "DELETE"}},
+			b:        map[string]interface{}{"ops": []interface{}{"DELETE", "INSERT"}},
+			expected: []string{"ops"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := normalize(tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := normalize(tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := deep.Equal(diffDocuments("", a, b), tt.expected); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestExpandJSONString(t *testing.T) {
+	got := expandJSONString(`{"a": {"$exists": true}}`)
+	expected := map[string]interface{}{"a": map[string]interface{}{"$exists": true}}
+	if diff := deep.Equal(got, expected); diff != nil {
+		t.Error(diff)
+	}
+
+	if got := expandJSONString("plain"); got != "plain" {
+		t.Errorf("expandJSONString(plain) = %v", got)
+	}
+	if got := expandJSONString("{not json"); got != "{not json" {
+		t.Errorf("expandJSONString({not json) = %v", got)
+	}
+}
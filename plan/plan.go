@@ -0,0 +1,344 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plan compares a local app configuration with a deployed app and
+// reports the creates, updates and deletes needed to reconcile them.
+package plan // import "github.com/mongodb-labs/go-client-mongodb-atlas-app-services/plan"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appconfig"
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appservices"
+)
+
+// databaseTriggerType is the type of the triggers watching a collection of a data source.
+const databaseTriggerType = "DATABASE"
+
+// Action is the kind of change applied to a resource.
+type Action string
+
+const (
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+)
+
+// Resource is the kind of resource a change applies to.
+type Resource string
+
+const (
+	AppResource     Resource = "app"
+	TriggerResource Resource = "trigger"
+)
+
+// Change describes a single create, update or delete of a resource.
+type Change struct {
+	Action   Action
+	Resource Resource
+	Name     string
+	// ID of the deployed resource, empty for creates.
+	ID string
+	// Fields lists the dotted paths of the fields that differ, for updates.
+	Fields []string
+
+	// App is the desired app settings for an AppResource update.
+	App *appservices.ApplicationRequest
+	// Trigger is the desired trigger for a TriggerResource create or update.
+	Trigger *appservices.EventTriggerRequest
+}
+
+// Plan is the ordered list of changes that reconcile a deployed app with a desired configuration.
+type Plan struct {
+	Changes []Change
+}
+
+// Empty reports whether the plan has no changes.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String renders the plan one change per line, prefixed by "+", "~" or "-".
+func (p *Plan) String() string {
+	var b strings.Builder
+	for i := range p.Changes {
+		c := &p.Changes[i]
+		switch c.Action {
+		case Create:
+			fmt.Fprintf(&b, "+ %s %s\n", c.Resource, c.Name)
+		case Update:
+			fmt.Fprintf(&b, "~ %s %s (%s)\n", c.Resource, c.Name, strings.Join(c.Fields, ", "))
+		case Delete:
+			fmt.Fprintf(&b, "- %s %s\n", c.Resource, c.Name)
+		}
+	}
+	return b.String()
+}
+
+// State is the deployed configuration of an app.
+type State struct {
+	App       *appservices.Application
+	Functions []appservices.Function
+	Services  []appservices.Service
+	Triggers  []appservices.EventTrigger
+}
+
+// Fetch reads the deployed configuration of an app.
+func Fetch(ctx context.Context, client *appservices.Client, groupID, appID string) (*State, error) {
+	app, _, err := client.Apps.Get(ctx, groupID, appID)
+	if err != nil {
+		return nil, err
+	}
+	functions, _, err := client.Functions.List(ctx, groupID, appID)
+	if err != nil {
+		return nil, err
+	}
+	services, _, err := client.Services.List(ctx, groupID, appID)
+	if err != nil {
+		return nil, err
+	}
+	triggers, _, err := client.EventTriggers.List(ctx, groupID, appID)
+	if err != nil {
+		return nil, err
+	}
+	return &State{App: app, Functions: functions, Services: services, Triggers: triggers}, nil
+}
+
+// Compute returns the changes needed to turn deployed into desired.
+//
+// The local configuration refers to other resources by name: a trigger's
+// function and data source names are resolved to the IDs of the deployed
+// function and service. Fields that identify other resources (a trigger's
+// function_id, service_id and clusterName) are otherwise inherited from the
+// deployed resource when the desired one leaves them unset.
+func Compute(desired *appconfig.App, deployed *State) (*Plan, error) {
+	if desired == nil || deployed == nil {
+		return nil, fmt.Errorf("plan: desired and deployed configurations must be set")
+	}
+
+	p := &Plan{}
+
+	if deployed.App != nil {
+		c, err := appChange(&desired.Config, deployed.App)
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			p.Changes = append(p.Changes, *c)
+		}
+	}
+
+	changes, err := triggerChanges(desired.Triggers, deployed)
+	if err != nil {
+		return nil, err
+	}
+	p.Changes = append(p.Changes, changes...)
+
+	return p, nil
+}
+
+func appChange(desired *appconfig.AppConfig, deployed *appservices.Application) (*Change, error) {
+	want := &appservices.ApplicationRequest{
+		Name:            desired.Name,
+		Location:        desired.Location,
+		DeploymentModel: desired.DeploymentModel,
+		ProviderRegion:  desired.ProviderRegion,
+	}
+	have := &appservices.ApplicationRequest{
+		Name:            deployed.Name,
+		Location:        deployed.Location,
+		DeploymentModel: deployed.DeploymentModel,
+		ProviderRegion:  deployed.ProviderRegion,
+	}
+	// Settings left out of realm_config.json are not managed.
+	if want.Name == "" {
+		have.Name = ""
+	}
+	if want.Location == "" {
+		have.Location = ""
+	}
+	if want.DeploymentModel == "" {
+		have.DeploymentModel = ""
+	}
+	if want.ProviderRegion == "" {
+		have.ProviderRegion = ""
+	}
+
+	fields, err := diff(want, have)
+	if err != nil || len(fields) == 0 {
+		return nil, err
+	}
+	return &Change{
+		Action:   Update,
+		Resource: AppResource,
+		Name:     deployed.Name,
+		ID:       deployed.ID,
+		Fields:   fields,
+		App:      want,
+	}, nil
+}
+
+func triggerChanges(desired []appconfig.Trigger, state *State) ([]Change, error) {
+	deployed := state.Triggers
+	functionIDs := make(map[string]string, len(state.Functions))
+	for i := range state.Functions {
+		functionIDs[state.Functions[i].Name] = state.Functions[i].ID
+	}
+	serviceIDs := make(map[string]string, len(state.Services))
+	for i := range state.Services {
+		serviceIDs[state.Services[i].Name] = state.Services[i].ID
+	}
+	current := make(map[string]*appservices.EventTrigger, len(deployed))
+	for i := range deployed {
+		t := &deployed[i]
+		current[t.Name] = t
+		if _, ok := functionIDs[t.FunctionName]; !ok && t.FunctionName != "" && t.FunctionID != "" {
+			functionIDs[t.FunctionName] = t.FunctionID
+		}
+	}
+
+	var changes []Change
+	seen := make(map[string]bool, len(desired))
+	for i := range desired {
+		t := &desired[i]
+		if t.Name == "" {
+			return nil, fmt.Errorf("plan: trigger %d has no name", i)
+		}
+		if seen[t.Name] {
+			return nil, fmt.Errorf("plan: duplicate trigger %q", t.Name)
+		}
+		seen[t.Name] = true
+
+		want := triggerRequest(&t.EventTrigger)
+		if want.FunctionID == "" && t.FunctionName != "" {
+			want.FunctionID = functionIDs[t.FunctionName]
+		}
+		if want.Config.ServiceID == "" && t.ServiceName != "" {
+			want.Config.ServiceID = serviceIDs[t.ServiceName]
+		}
+
+		have, ok := current[t.Name]
+		if !ok {
+			if err := checkTriggerIdentifiers(t, want); err != nil {
+				return nil, err
+			}
+			changes = append(changes, Change{Action: Create, Resource: TriggerResource, Name: t.Name, Trigger: want})
+			continue
+		}
+
+		inheritTriggerIdentifiers(want, have)
+		fields, err := diff(withConfigDefaults(want), withConfigDefaults(triggerRequest(have)))
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) > 0 {
+			changes = append(changes, Change{Action: Update, Resource: TriggerResource, Name: t.Name, ID: have.ID, Fields: fields, Trigger: want})
+		}
+	}
+
+	for i := range deployed {
+		t := &deployed[i]
+		if !seen[t.Name] {
+			changes = append(changes, Change{Action: Delete, Resource: TriggerResource, Name: t.Name, ID: t.ID})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes, nil
+}
+
+// triggerRequest returns the request that would create t, with its free-form
+// fields expanded and its disabled flag defaulted so both sides compare alike.
+func triggerRequest(t *appservices.EventTrigger) *appservices.EventTriggerRequest {
+	config := t.Config
+	config.Match = expandJSONString(config.Match)
+	config.Project = expandJSONString(config.Project)
+
+	disabled := false
+	if t.Disabled != nil {
+		disabled = *t.Disabled
+	}
+
+	return &appservices.EventTriggerRequest{
+		Config:          &config,
+		EventProcessors: t.EventProcessors,
+		Disabled:        &disabled,
+		Name:            t.Name,
+		Type:            t.Type,
+		FunctionID:      t.FunctionID,
+	}
+}
+
+// withConfigDefaults returns a copy of r whose unset config flags are set to
+// false, the value the server assumes for them, so that an absent flag and an
+// explicit false compare alike.
+func withConfigDefaults(r *appservices.EventTriggerRequest) *appservices.EventTriggerRequest {
+	out := *r
+	config := *r.Config
+	for _, flag := range []**bool{
+		&config.Unordered,
+		&config.FullDocument,
+		&config.FullDocumentBeforeChange,
+		&config.TolerateResumeErrors,
+		&config.SkipCatchupEvents,
+		&config.MaximumThroughput,
+	} {
+		if *flag == nil {
+			*flag = new(bool)
+		}
+	}
+	out.Config = &config
+	return &out
+}
+
+// checkTriggerIdentifiers returns an error when the trigger to create references
+// a function or data source that could not be resolved.
+func checkTriggerIdentifiers(t *appconfig.Trigger, want *appservices.EventTriggerRequest) error {
+	if want.FunctionID == "" {
+		return fmt.Errorf("plan: trigger %q references unknown function %q", t.Name, t.FunctionName)
+	}
+	if want.Config.ServiceID == "" && (t.ServiceName != "" || t.Type == databaseTriggerType) {
+		return fmt.Errorf("plan: trigger %q references unknown service %q", t.Name, t.ServiceName)
+	}
+	return nil
+}
+
+func inheritTriggerIdentifiers(want *appservices.EventTriggerRequest, have *appservices.EventTrigger) {
+	if want.FunctionID == "" {
+		want.FunctionID = have.FunctionID
+	}
+	if want.Config.ServiceID == "" {
+		want.Config.ServiceID = have.Config.ServiceID
+	}
+	if want.Config.ClusterName == "" {
+		want.Config.ClusterName = have.Config.ClusterName
+	}
+}
+
+func diff(want, have interface{}) ([]string, error) {
+	w, err := normalize(want)
+	if err != nil {
+		return nil, err
+	}
+	h, err := normalize(have)
+	if err != nil {
+		return nil, err
+	}
+	return diffDocuments("", w, h), nil
+}
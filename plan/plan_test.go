@@ -0,0 +1,348 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appconfig"
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appservices"
+)
+
+const (
+	groupID = "6c7498dg87d9e6526801572b"
+	appID   = "5c7498dg87d9e6526801572b"
+)
+
+func deployedState() *State {
+	return &State{
+		App: &appservices.Application{ID: appID, Name: "myapp", Location: "US-VA", DeploymentModel: "GLOBAL"},
+		Triggers: []appservices.EventTrigger{
+			{
+				ID:           "1",
+				Name:         "unchanged",
+				Type:         "DATABASE",
+				FunctionID:   "f1",
+				FunctionName: "handle",
+				Disabled:     pointer(false),
+				Config: appservices.EventTriggerConfig{
+					ServiceID:      "s1",
+					Database:       "db",
+					Collection:     "coll",
+					OperationTypes: []string{"INSERT"},
+					Match:          map[string]interface{}{"a": 1.0, "b": map[string]interface{}{"$exists": true}},
+					FullDocument:   pointer(false),
+					Unordered:      pointer(false),
+				},
+				EventProcessors: map[string]interface{}{
+					"AWS_EVENTBRIDGE": map[string]interface{}{
+						"config": map[string]interface{}{"region": "us-east-1", "account_id": "012345678901"},
+					},
+				},
+			},
+			{
+				ID:           "2",
+				Name:         "changed",
+				Type:         "SCHEDULED",
+				FunctionID:   "f1",
+				FunctionName: "handle",
+				Config:       appservices.EventTriggerConfig{Schedule: "*/5 * * * *"},
+			},
+			{
+				ID:           "3",
+				Name:         "removed",
+				Type:         "SCHEDULED",
+				FunctionID:   "f1",
+				FunctionName: "handle",
+				Config:       appservices.EventTriggerConfig{Schedule: "0 * * * *"},
+			},
+		},
+	}
+}
+
+func desiredApp() *appconfig.App {
+	return &appconfig.App{
+		Config: appconfig.AppConfig{Name: "myapp", Location: "US-VA"},
+		Triggers: []appconfig.Trigger{
+			{EventTrigger: appservices.EventTrigger{
+				Name:         "unchanged",
+				Type:         "DATABASE",
+				FunctionName: "handle",
+				Config: appservices.EventTriggerConfig{
+					Database:       "db",
+					Collection:     "coll",
+					OperationTypes: []string{"INSERT"},
+					Match:          `{"b": {"$exists": true}, "a": 1}`,
+				},
+				EventProcessors: map[string]interface{}{
+					"AWS_EVENTBRIDGE": map[string]interface{}{
+						"config": map[string]interface{}{"account_id": "012345678901", "region": "us-east-1"},
+					},
+				},
+			}},
+			{EventTrigger: appservices.EventTrigger{
+				Name:         "changed",
+				Type:         "SCHEDULED",
+				FunctionName: "handle",
+				Disabled:     pointer(true),
+				Config:       appservices.EventTriggerConfig{Schedule: "*/10 * * * *"},
+			}},
+			{EventTrigger: appservices.EventTrigger{
+				Name:         "added",
+				Type:         "SCHEDULED",
+				FunctionName: "handle",
+				Config:       appservices.EventTriggerConfig{Schedule: "0 0 * * *"},
+			}},
+		},
+	}
+}
+
+func TestCompute(t *testing.T) {
+	p, err := Compute(desiredApp(), deployedState())
+	if err != nil {
+		t.Fatalf("Compute returned error: %v", err)
+	}
+
+	expected := []Change{
+		{
+			Action:   Create,
+			Resource: TriggerResource,
+			Name:     "added",
+			Trigger: &appservices.EventTriggerRequest{
+				Name:       "added",
+				Type:       "SCHEDULED",
+				FunctionID: "f1",
+				Disabled:   pointer(false),
+				Config:     &appservices.EventTriggerConfig{Schedule: "0 0 * * *"},
+			},
+		},
+		{
+			Action:   Update,
+			Resource: TriggerResource,
+			Name:     "changed",
+			ID:       "2",
+			Fields:   []string{"config.schedule", "disabled"},
+			Trigger: &appservices.EventTriggerRequest{
+				Name:       "changed",
+				Type:       "SCHEDULED",
+				FunctionID: "f1",
+				Disabled:   pointer(true),
+				Config:     &appservices.EventTriggerConfig{Schedule: "*/10 * * * *"},
+			},
+		},
+		{
+			Action:   Delete,
+			Resource: TriggerResource,
+			Name:     "removed",
+			ID:       "3",
+		},
+	}
+
+	if diff := deep.Equal(p.Changes, expected); diff != nil {
+		t.Error(diff)
+	}
+
+	expectedString := "+ trigger added\n~ trigger changed (config.schedule, disabled)\n- trigger removed\n"
+	if got := p.String(); got != expectedString {
+		t.Errorf("String() = %q, expected %q", got, expectedString)
+	}
+}
+
+func TestCompute_configFlags(t *testing.T) {
+	deployed := &State{Triggers: []appservices.EventTrigger{{
+		ID:         "1",
+		Name:       "t",
+		Type:       "DATABASE",
+		FunctionID: "f1",
+		Config:     appservices.EventTriggerConfig{FullDocument: pointer(false), Unordered: pointer(true)},
+	}}}
+	desired := &appconfig.App{Triggers: []appconfig.Trigger{{EventTrigger: appservices.EventTrigger{
+		Name:       "t",
+		Type:       "DATABASE",
+		FunctionID: "f1",
+		Config:     appservices.EventTriggerConfig{SkipCatchupEvents: pointer(false)},
+	}}}}
+
+	p, err := Compute(desired, deployed)
+	if err != nil {
+		t.Fatalf("Compute returned error: %v", err)
+	}
+
+	// An unset flag matches the server default of false, but not true.
+	expected := "~ trigger t (config.unordered)\n"
+	if got := p.String(); got != expected {
+		t.Errorf("String() = %q, expected %q", got, expected)
+	}
+}
+
+func TestCompute_appSettings(t *testing.T) {
+	desired := &appconfig.App{Config: appconfig.AppConfig{Name: "renamed", DeploymentModel: "LOCAL"}}
+	deployed := &State{App: &appservices.Application{ID: appID, Name: "myapp", Location: "US-VA", DeploymentModel: "GLOBAL"}}
+
+	p, err := Compute(desired, deployed)
+	if err != nil {
+		t.Fatalf("Compute returned error: %v", err)
+	}
+
+	expected := []Change{
+		{
+			Action:   Update,
+			Resource: AppResource,
+			Name:     "myapp",
+			ID:       appID,
+			Fields:   []string{"deployment_model", "name"},
+			App:      &appservices.ApplicationRequest{Name: "renamed", DeploymentModel: "LOCAL"},
+		},
+	}
+
+	if diff := deep.Equal(p.Changes, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestCompute_unmanagedAppSettings(t *testing.T) {
+	desired := &appconfig.App{Config: appconfig.AppConfig{Location: "US-VA"}}
+	deployed := &State{App: &appservices.Application{ID: appID, Name: "myapp", Location: "US-VA", DeploymentModel: "GLOBAL"}}
+
+	p, err := Compute(desired, deployed)
+	if err != nil {
+		t.Fatalf("Compute returned error: %v", err)
+	}
+	if !p.Empty() {
+		t.Errorf("expected no changes for settings left out of realm_config.json, got %s", p)
+	}
+}
+
+func TestCompute_functionFromState(t *testing.T) {
+	desired := &appconfig.App{
+		Triggers: []appconfig.Trigger{{EventTrigger: appservices.EventTrigger{Name: "t", Type: "SCHEDULED", FunctionName: "standalone"}}},
+	}
+	deployed := &State{Functions: []appservices.Function{{ID: "f2", Name: "standalone"}}}
+
+	p, err := Compute(desired, deployed)
+	if err != nil {
+		t.Fatalf("Compute returned error: %v", err)
+	}
+	if len(p.Changes) != 1 || p.Changes[0].Trigger.FunctionID != "f2" {
+		t.Errorf("expected a create referencing function f2, got %+v", p.Changes)
+	}
+}
+
+func TestCompute_unknownFunction(t *testing.T) {
+	desired := &appconfig.App{
+		Triggers: []appconfig.Trigger{{EventTrigger: appservices.EventTrigger{Name: "t", Type: "SCHEDULED", FunctionName: "missing"}}},
+	}
+
+	if _, err := Compute(desired, &State{}); err == nil {
+		t.Error("expected an error for a trigger referencing an unknown function")
+	}
+}
+
+func TestCompute_serviceFromState(t *testing.T) {
+	trigger := appconfig.Trigger{
+		EventTrigger: appservices.EventTrigger{
+			Name:         "t",
+			Type:         "DATABASE",
+			FunctionName: "handle",
+			Config:       appservices.EventTriggerConfig{Database: "db", Collection: "coll"},
+		},
+		ServiceName: "mongodb-atlas",
+	}
+	deployed := &State{
+		Functions: []appservices.Function{{ID: "f1", Name: "handle"}},
+		Services:  []appservices.Service{{ID: "s1", Name: "mongodb-atlas", Type: "mongodb-atlas"}},
+	}
+
+	p, err := Compute(&appconfig.App{Triggers: []appconfig.Trigger{trigger}}, deployed)
+	if err != nil {
+		t.Fatalf("Compute returned error: %v", err)
+	}
+	if len(p.Changes) != 1 || p.Changes[0].Trigger.Config.ServiceID != "s1" {
+		t.Errorf("expected a create referencing service s1, got %+v", p.Changes)
+	}
+
+	trigger.ServiceName = "missing"
+	if _, err := Compute(&appconfig.App{Triggers: []appconfig.Trigger{trigger}}, deployed); err == nil {
+		t.Error("expected an error for a trigger referencing an unknown service")
+	}
+
+	trigger.ServiceName = ""
+	if _, err := Compute(&appconfig.App{Triggers: []appconfig.Trigger{trigger}}, deployed); err == nil {
+		t.Error("expected an error for a database trigger without a service")
+	}
+}
+
+func TestCompute_duplicateTrigger(t *testing.T) {
+	desired := &appconfig.App{
+		Triggers: []appconfig.Trigger{
+			{EventTrigger: appservices.EventTrigger{Name: "t", FunctionID: "f1"}},
+			{EventTrigger: appservices.EventTrigger{Name: "t", FunctionID: "f1"}},
+		},
+	}
+
+	if _, err := Compute(desired, &State{}); err == nil {
+		t.Error("expected an error for duplicate triggers")
+	}
+}
+
+func TestFetch(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc(fmt.Sprintf("/groups/%s/apps/%s", groupID, appID), func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"_id": %q, "name": "myapp"}`, appID)
+	})
+	mux.HandleFunc(fmt.Sprintf("/groups/%s/apps/%s/functions", groupID, appID), func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[{"_id": "f1", "name": "handle"}]`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/groups/%s/apps/%s/services", groupID, appID), func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[{"_id": "s1", "name": "mongodb-atlas", "type": "mongodb-atlas"}]`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/groups/%s/apps/%s/triggers", groupID, appID), func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[{"_id": "1", "name": "t"}]`)
+	})
+
+	client, err := appservices.New(nil, appservices.SetBaseURL(server.URL+"/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := Fetch(context.Background(), client, groupID, appID)
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+
+	expected := &State{
+		App:       &appservices.Application{ID: appID, Name: "myapp"},
+		Functions: []appservices.Function{{ID: "f1", Name: "handle"}},
+		Services:  []appservices.Service{{ID: "s1", Name: "mongodb-atlas", Type: "mongodb-atlas"}},
+		Triggers:  []appservices.EventTrigger{{ID: "1", Name: "t"}},
+	}
+
+	if diff := deep.Equal(state, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func pointer[T any](x T) *T {
+	return &x
+}
@@ -0,0 +1,215 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemagen
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appservices"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// bsonPackageTypes maps the BSON types of the MongoDB Go driver, matched by
+// name so this package does not depend on the driver.
+var bsonPackageTypes = map[string]string{
+	"ObjectID":   BSONTypeObjectID,
+	"Decimal128": BSONTypeDecimal,
+	"Timestamp":  BSONTypeTimestamp,
+	"DateTime":   BSONTypeDate,
+	"Binary":     BSONTypeBinData,
+}
+
+// bsonPackages are the import paths of the driver packages declaring the types of bsonPackageTypes.
+var bsonPackages = map[string]bool{
+	"go.mongodb.org/mongo-driver/bson":           true,
+	"go.mongodb.org/mongo-driver/bson/primitive": true,
+	"go.mongodb.org/mongo-driver/v2/bson":        true,
+}
+
+// kindBSONTypes maps the scalar Go kinds to a BSON type.
+var kindBSONTypes = map[reflect.Kind]string{
+	reflect.Bool:      BSONTypeBool,
+	reflect.Int8:      BSONTypeInt,
+	reflect.Int16:     BSONTypeInt,
+	reflect.Int32:     BSONTypeInt,
+	reflect.Uint8:     BSONTypeInt,
+	reflect.Uint16:    BSONTypeInt,
+	reflect.Int:       BSONTypeLong,
+	reflect.Int64:     BSONTypeLong,
+	reflect.Uint:      BSONTypeLong,
+	reflect.Uint32:    BSONTypeLong,
+	reflect.Uint64:    BSONTypeLong,
+	reflect.Float32:   BSONTypeDouble,
+	reflect.Float64:   BSONTypeDouble,
+	reflect.String:    BSONTypeString,
+	reflect.Interface: BSONTypeMixed,
+}
+
+// SchemaOf returns the App Services schema of the struct v, or of the struct v points to.
+//
+// Property names are read from the bson struct tag, then the json struct tag,
+// and default to the lower-cased field name like the MongoDB Go driver does.
+// Fields tagged "-" and unexported fields are skipped, and embedded structs
+// without a name are inlined. Pointer fields and fields tagged omitempty are
+// optional; every other field is required.
+func SchemaOf(v interface{}) (*appservices.JSONSchema, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("schemagen: SchemaOf expects a struct, got %v", t)
+	}
+
+	schema, err := schemaOf(t, map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+	schema.Title = t.Name()
+
+	return schema, nil
+}
+
+func schemaOf(t reflect.Type, visiting map[reflect.Type]bool) (*appservices.JSONSchema, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return &appservices.JSONSchema{BSONType: BSONTypeDate}, nil
+	}
+	if bsonType, ok := bsonPackageTypes[t.Name()]; ok && isBSONPackage(t.PkgPath()) {
+		return &appservices.JSONSchema{BSONType: bsonType}, nil
+	}
+	if bsonType, ok := kindBSONTypes[t.Kind()]; ok {
+		return &appservices.JSONSchema{BSONType: bsonType}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &appservices.JSONSchema{BSONType: BSONTypeBinData}, nil
+		}
+		items, err := schemaOf(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return &appservices.JSONSchema{BSONType: BSONTypeArray, Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("schemagen: unsupported map key type %v", t.Key())
+		}
+		values, err := schemaOf(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return &appservices.JSONSchema{BSONType: BSONTypeObject, AdditionalProperties: values}, nil
+	case reflect.Struct:
+		return structSchema(t, visiting)
+	}
+
+	return nil, fmt.Errorf("schemagen: unsupported type %v", t)
+}
+
+func structSchema(t reflect.Type, visiting map[reflect.Type]bool) (*appservices.JSONSchema, error) {
+	if visiting[t] {
+		return nil, fmt.Errorf("schemagen: recursive type %v", t)
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	schema := &appservices.JSONSchema{BSONType: BSONTypeObject, Properties: map[string]*appservices.JSONSchema{}}
+	if err := addFields(schema, t, visiting); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// addFields adds the properties of the fields of the struct type t to schema.
+func addFields(schema *appservices.JSONSchema, t reflect.Type, visiting map[reflect.Type]bool) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitEmpty, inline, skip := fieldTag(field)
+		if skip {
+			continue
+		}
+
+		if field.Anonymous && inline {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := addFields(schema, embedded, visiting); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		property, err := schemaOf(field.Type, visiting)
+		if err != nil {
+			return fmt.Errorf("field %s.%s: %w", t.Name(), field.Name, err)
+		}
+		schema.Properties[name] = property
+		if !omitEmpty && field.Type.Kind() != reflect.Ptr {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return nil
+}
+
+// fieldTag parses the bson, or json, struct tag of field.
+func fieldTag(field reflect.StructField) (name string, omitEmpty, inline, skip bool) {
+	tag, ok := field.Tag.Lookup("bson")
+	if !ok {
+		tag, ok = field.Tag.Lookup("json")
+	}
+	if tag == "-" {
+		return "", false, false, true
+	}
+
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	for _, option := range parts[1:] {
+		switch option {
+		case "omitempty":
+			omitEmpty = true
+		case "inline":
+			inline = true
+		}
+	}
+	// Embedded structs are inlined unless the tag names them.
+	if field.Anonymous && (!ok || name == "") {
+		inline = true
+	}
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+
+	return name, omitEmpty, inline, false
+}
+
+func isBSONPackage(pkgPath string) bool {
+	return bsonPackages[pkgPath]
+}
@@ -0,0 +1,145 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemagen
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appservices"
+)
+
+type audit struct {
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+type customer struct {
+	audit    `bson:",inline"`
+	ID       string            `bson:"_id"`
+	Name     string            `json:"name"`
+	Email    *string           `bson:"email"`
+	Tags     []string          `bson:"tags,omitempty"`
+	Avatar   []byte            `bson:"avatar,omitempty"`
+	Labels   map[string]int32  `bson:"labels,omitempty"`
+	Extra    interface{}       `bson:"extra,omitempty"`
+	Address  address           `bson:"address"`
+	Orders   []order           `bson:"orders"`
+	Balance  float64           `bson:"balance"`
+	Points   int64             `bson:"points"`
+	Verified bool              `bson:"verified"`
+	Internal string            `bson:"-"`
+	Ignored  map[string]string `json:"-"`
+}
+
+type address struct {
+	City string
+}
+
+type order struct {
+	Total int `bson:"total"`
+}
+
+type node struct {
+	Children []node `bson:"children"`
+}
+
+func TestSchemaOf(t *testing.T) {
+	schema, err := SchemaOf(&customer{})
+	if err != nil {
+		t.Fatalf("SchemaOf returned error: %v", err)
+	}
+
+	expected := &appservices.JSONSchema{
+		Title:    "customer",
+		BSONType: BSONTypeObject,
+		Required: []string{"created_at", "_id", "name", "address", "orders", "balance", "points", "verified"},
+		Properties: map[string]*appservices.JSONSchema{
+			"created_at": {BSONType: BSONTypeDate},
+			"_id":        {BSONType: BSONTypeString},
+			"name":       {BSONType: BSONTypeString},
+			"email":      {BSONType: BSONTypeString},
+			"tags":       {BSONType: BSONTypeArray, Items: &appservices.JSONSchema{BSONType: BSONTypeString}},
+			"avatar":     {BSONType: BSONTypeBinData},
+			"labels":     {BSONType: BSONTypeObject, AdditionalProperties: &appservices.JSONSchema{BSONType: BSONTypeInt}},
+			"extra":      {BSONType: BSONTypeMixed},
+			"address": {
+				BSONType:   BSONTypeObject,
+				Required:   []string{"city"},
+				Properties: map[string]*appservices.JSONSchema{"city": {BSONType: BSONTypeString}},
+			},
+			"orders": {
+				BSONType: BSONTypeArray,
+				Items: &appservices.JSONSchema{
+					BSONType:   BSONTypeObject,
+					Required:   []string{"total"},
+					Properties: map[string]*appservices.JSONSchema{"total": {BSONType: BSONTypeLong}},
+				},
+			},
+			"balance":  {BSONType: BSONTypeDouble},
+			"points":   {BSONType: BSONTypeLong},
+			"verified": {BSONType: BSONTypeBool},
+		},
+	}
+
+	if diff := deep.Equal(schema, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestSchemaOf_errors(t *testing.T) {
+	if _, err := SchemaOf("not a struct"); err == nil {
+		t.Error("expected an error for a non struct value")
+	}
+	if _, err := SchemaOf(node{}); err == nil {
+		t.Error("expected an error for a recursive type")
+	}
+	if _, err := SchemaOf(struct{ C chan int }{}); err == nil {
+		t.Error("expected an error for an unsupported type")
+	}
+}
+
+func TestIsBSONPackage(t *testing.T) {
+	for pkgPath, expected := range map[string]bool{
+		"go.mongodb.org/mongo-driver/bson/primitive": true,
+		"go.mongodb.org/mongo-driver/bson":           true,
+		"go.mongodb.org/mongo-driver/v2/bson":        true,
+		"example.com/store/bson":                     false,
+		"example.com/store/bson/primitive":           false,
+	} {
+		if got := isBSONPackage(pkgPath); got != expected {
+			t.Errorf("isBSONPackage(%q) = %v, expected %v", pkgPath, got, expected)
+		}
+	}
+}
+
+func TestSchemaOf_roundTrip(t *testing.T) {
+	schema, err := SchemaOf(order{})
+	if err != nil {
+		t.Fatalf("SchemaOf returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Generate(&buf, schema, &Options{TypeName: "Order"}); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+
+	expected := "// Code generated by schemagen. DO NOT EDIT.\n\npackage models\n\n" +
+		"type Order struct {\n\tTotal int64 `json:\"total\" bson:\"total\"`\n}\n"
+	if got := buf.String(); got != expected {
+		t.Errorf("Generate returned:\n%s\nexpected:\n%s", got, expected)
+	}
+}
@@ -0,0 +1,436 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schemagen converts between App Services collection schemas and Go
+// struct types.
+//
+// Generate writes the Go source of the structs described by a schema, and
+// SchemaOf builds the schema describing a Go struct, so either side can be the
+// source of truth. BSON types without a Go counterpart in the standard library,
+// such as objectId and decimal, map to the types of the MongoDB Go driver's
+// bson/primitive package.
+package schemagen // import "github.com/mongodb-labs/go-client-mongodb-atlas-app-services/schemagen"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appservices"
+)
+
+// BSON types of an App Services schema.
+const (
+	BSONTypeObject    = "object"
+	BSONTypeArray     = "array"
+	BSONTypeString    = "string"
+	BSONTypeBool      = "bool"
+	BSONTypeInt       = "int"
+	BSONTypeLong      = "long"
+	BSONTypeDouble    = "double"
+	BSONTypeDecimal   = "decimal"
+	BSONTypeObjectID  = "objectId"
+	BSONTypeDate      = "date"
+	BSONTypeTimestamp = "timestamp"
+	BSONTypeBinData   = "binData"
+	BSONTypeMixed     = "mixed"
+	BSONTypeNull      = "null"
+)
+
+const (
+	primitivePackage = "go.mongodb.org/mongo-driver/bson/primitive"
+	timePackage      = "time"
+)
+
+// goTypes maps the scalar BSON types to a Go type and the package it must import.
+var goTypes = map[string]struct{ name, pkg string }{
+	BSONTypeString:    {"string", ""},
+	BSONTypeBool:      {"bool", ""},
+	BSONTypeInt:       {"int32", ""},
+	BSONTypeLong:      {"int64", ""},
+	BSONTypeDouble:    {"float64", ""},
+	BSONTypeDecimal:   {"primitive.Decimal128", primitivePackage},
+	BSONTypeObjectID:  {"primitive.ObjectID", primitivePackage},
+	BSONTypeDate:      {"time.Time", timePackage},
+	BSONTypeTimestamp: {"primitive.Timestamp", primitivePackage},
+	BSONTypeBinData:   {"[]byte", ""},
+	BSONTypeMixed:     {"interface{}", ""},
+	"":                {"interface{}", ""},
+}
+
+// Options configures Generate.
+type Options struct {
+	// Package is the name of the generated package. Defaults to "models".
+	Package string
+	// TypeName is the name of the root struct. Defaults to the schema title.
+	TypeName string
+	// Generator is the name written in the "Code generated" header. Defaults to "schemagen".
+	Generator string
+}
+
+// ErrNoTypeName is returned by Generate when neither Options.TypeName nor the schema title is set.
+var ErrNoTypeName = errors.New("schemagen: no type name, set Options.TypeName or the schema title")
+
+type structType struct {
+	name        string
+	description string
+	fields      []structField
+}
+
+type structField struct {
+	name        string
+	goType      string
+	property    string
+	description string
+	required    bool
+}
+
+type generator struct {
+	imports map[string]bool
+	names   map[string]bool
+	structs []*structType
+}
+
+// Generate writes to w the gofmt-ed Go source of the struct described by the
+// object schema, along with one struct per nested object. Required properties
+// are value fields; optional properties are pointers, or nil-able slices and
+// maps, tagged with omitempty.
+func Generate(w io.Writer, schema *appservices.JSONSchema, opts *Options) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+	if schema == nil {
+		return errors.New("schemagen: nil schema")
+	}
+	if bsonType, _ := schemaType(schema); bsonType != "" && bsonType != BSONTypeObject {
+		return fmt.Errorf("schemagen: root schema has bsonType %q, expected %q", bsonType, BSONTypeObject)
+	}
+
+	typeName := opts.TypeName
+	if typeName == "" {
+		typeName = exportedName(schema.Title)
+	}
+	if typeName == "" {
+		return ErrNoTypeName
+	}
+	pkg := opts.Package
+	if pkg == "" {
+		pkg = "models"
+	}
+	generatorName := opts.Generator
+	if generatorName == "" {
+		generatorName = "schemagen"
+	}
+
+	g := &generator{imports: map[string]bool{}, names: map[string]bool{}}
+	if err := g.addStruct(typeName, schema); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by %s. DO NOT EDIT.\n\npackage %s\n\n", generatorName, pkg)
+	if len(g.imports) > 0 {
+		imports := make([]string, 0, len(g.imports))
+		for imp := range g.imports {
+			imports = append(imports, imp)
+		}
+		// Standard library imports first, then third party ones, as goimports does.
+		sort.Slice(imports, func(i, j int) bool {
+			if si, sj := isStdlib(imports[i]), isStdlib(imports[j]); si != sj {
+				return si
+			}
+			return imports[i] < imports[j]
+		})
+		buf.WriteString("import (\n")
+		for i, imp := range imports {
+			if i > 0 && isStdlib(imports[i-1]) && !isStdlib(imp) {
+				buf.WriteString("\n")
+			}
+			fmt.Fprintf(&buf, "%q\n", imp)
+		}
+		buf.WriteString(")\n\n")
+	}
+	for _, s := range g.structs {
+		writeStruct(&buf, s)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("schemagen: formatting generated source: %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// addStruct registers the struct described by the object schema under name.
+func (g *generator) addStruct(name string, schema *appservices.JSONSchema) error {
+	s := &structType{name: name, description: schema.Description}
+	g.names[name] = true
+	g.structs = append(g.structs, s)
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, property := range schema.Required {
+		required[property] = true
+	}
+
+	properties := make([]string, 0, len(schema.Properties))
+	for property := range schema.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+
+	fieldNames := map[string]bool{}
+	for _, property := range properties {
+		fieldName := uniqueName(exportedName(property), fieldNames)
+		fieldNames[fieldName] = true
+
+		propertySchema := schema.Properties[property]
+		if propertySchema == nil {
+			propertySchema = &appservices.JSONSchema{}
+		}
+		goType, err := g.goType(name+fieldName, propertySchema)
+		if err != nil {
+			return fmt.Errorf("property %q of %s: %w", property, name, err)
+		}
+		if !required[property] && !nillable(goType) {
+			goType = "*" + goType
+		}
+
+		s.fields = append(s.fields, structField{
+			name:        fieldName,
+			goType:      goType,
+			property:    property,
+			description: propertySchema.Description,
+			required:    required[property],
+		})
+	}
+
+	return nil
+}
+
+// goType returns the Go type of schema, registering a struct named name for
+// objects with properties. Schemas that allow null are pointers.
+func (g *generator) goType(name string, schema *appservices.JSONSchema) (string, error) {
+	bsonType, nullable := schemaType(schema)
+	goType, err := g.bsonGoType(name, bsonType, schema)
+	if err != nil {
+		return "", err
+	}
+	if nullable && !nillable(goType) {
+		goType = "*" + goType
+	}
+	return goType, nil
+}
+
+// bsonGoType returns the Go type of bsonType, described by schema.
+func (g *generator) bsonGoType(name, bsonType string, schema *appservices.JSONSchema) (string, error) {
+	switch bsonType {
+	case BSONTypeObject:
+		if len(schema.Properties) == 0 {
+			if values := additionalProperties(schema); values != nil {
+				elem, err := g.goType(name, values)
+				if err != nil {
+					return "", err
+				}
+				return "map[string]" + elem, nil
+			}
+			return "map[string]interface{}", nil
+		}
+		if schema.Title != "" {
+			name = exportedName(schema.Title)
+		}
+		name = uniqueName(name, g.names)
+		return name, g.addStruct(name, schema)
+	case BSONTypeArray:
+		if schema.Items == nil {
+			return "[]interface{}", nil
+		}
+		elem, err := g.goType(name, schema.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	}
+
+	t, ok := goTypes[bsonType]
+	if !ok {
+		return "", fmt.Errorf("unsupported bsonType %q", bsonType)
+	}
+	if t.pkg != "" {
+		g.imports[t.pkg] = true
+	}
+	return t.name, nil
+}
+
+// schemaType returns the BSON type of schema and whether it also allows null.
+// A schema allowing several types other than null is mixed.
+func schemaType(schema *appservices.JSONSchema) (string, bool) {
+	if len(schema.BSONTypes) == 0 {
+		return schema.BSONType, false
+	}
+	var types []string
+	nullable := false
+	for _, t := range schema.BSONTypes {
+		if t == BSONTypeNull {
+			nullable = true
+			continue
+		}
+		types = append(types, t)
+	}
+	if len(types) != 1 {
+		return BSONTypeMixed, nullable
+	}
+	return types[0], nullable
+}
+
+func writeStruct(buf *bytes.Buffer, s *structType) {
+	writeComment(buf, s.name, s.description, "")
+	fmt.Fprintf(buf, "type %s struct {\n", s.name)
+	for _, f := range s.fields {
+		writeComment(buf, f.name, f.description, "\t")
+		tag := f.property
+		if !f.required {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(buf, "\t%s %s `json:%s bson:%s`\n", f.name, f.goType, strconv.Quote(tag), strconv.Quote(tag))
+	}
+	buf.WriteString("}\n\n")
+}
+
+func writeComment(buf *bytes.Buffer, name, description, indent string) {
+	if description == "" {
+		return
+	}
+	for i, line := range strings.Split(strings.TrimSpace(description), "\n") {
+		if i == 0 {
+			line = name + ": " + line
+		}
+		fmt.Fprintf(buf, "%s// %s\n", indent, strings.TrimSpace(line))
+	}
+}
+
+// additionalProperties returns the schema of the values of an object without
+// properties, or nil if the values are not described.
+func additionalProperties(schema *appservices.JSONSchema) *appservices.JSONSchema {
+	switch v := schema.AdditionalProperties.(type) {
+	case *appservices.JSONSchema:
+		return v
+	case map[string]interface{}:
+		// Decoded from JSON, convert it back into a schema.
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil
+		}
+		values := new(appservices.JSONSchema)
+		if err := json.Unmarshal(b, values); err != nil {
+			return nil
+		}
+		return values
+	}
+	return nil
+}
+
+func isStdlib(importPath string) bool {
+	return !strings.Contains(strings.SplitN(importPath, "/", 2)[0], ".")
+}
+
+// nillable reports whether the zero value of goType already marks an absent value.
+func nillable(goType string) bool {
+	return strings.HasPrefix(goType, "*") || strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[") ||
+		goType == "interface{}"
+}
+
+// commonInitialisms are written in upper case in Go identifiers.
+var commonInitialisms = map[string]bool{
+	"API": true, "DB": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true,
+	"IP": true, "JSON": true, "SQL": true, "TTL": true, "UI": true, "URI": true,
+	"URL": true, "UUID": true, "XML": true,
+}
+
+// exportedName converts a property name such as "_id" or "customer_id" into an
+// exported Go identifier such as "ID" or "CustomerID".
+func exportedName(property string) string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+	}
+	runes := []rune(property)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case startsWord(runes, i):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+
+	var name strings.Builder
+	for _, w := range words {
+		if upper := strings.ToUpper(w); commonInitialisms[upper] {
+			name.WriteString(upper)
+			continue
+		}
+		r := []rune(strings.ToLower(w))
+		r[0] = unicode.ToUpper(r[0])
+		name.WriteString(string(r))
+	}
+	if name.Len() == 0 {
+		return ""
+	}
+	if s := name.String(); unicode.IsDigit([]rune(s)[0]) {
+		return "X" + s
+	}
+	return name.String()
+}
+
+// startsWord reports whether the upper case rune at i starts a new word, as in
+// "customerId" or the "Id" of "URLId".
+func startsWord(runes []rune, i int) bool {
+	if i == 0 || !unicode.IsUpper(runes[i]) {
+		return false
+	}
+	if unicode.IsLower(runes[i-1]) {
+		return true
+	}
+	return i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1])
+}
+
+func uniqueName(name string, taken map[string]bool) string {
+	if name == "" {
+		name = "X"
+	}
+	if !taken[name] {
+		return name
+	}
+	for i := 2; ; i++ {
+		if candidate := name + strconv.Itoa(i); !taken[candidate] {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemagen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appservices"
+)
+
+const orderSchema = `{
+  "title": "order",
+  "description": "An order placed by a customer.",
+  "bsonType": "object",
+  "required": ["_id", "customer_id", "placed_at", "lines"],
+  "properties": {
+    "_id": {"bsonType": "objectId"},
+    "customer_id": {"bsonType": "objectId", "description": "The customer who placed the order."},
+    "placed_at": {"bsonType": "date"},
+    "total": {"bsonType": "decimal"},
+    "notes": {"bsonType": "string"},
+    "metadata": {"bsonType": "object", "additionalProperties": {"bsonType": "string"}},
+    "lines": {
+      "bsonType": "array",
+      "items": {
+        "bsonType": "object",
+        "required": ["sku", "quantity"],
+        "properties": {
+          "sku": {"bsonType": "string"},
+          "quantity": {"bsonType": "int"},
+          "giftWrap": {"bsonType": "bool"}
+        }
+      }
+    },
+    "shipping_address": {
+      "title": "Address",
+      "bsonType": "object",
+      "properties": {
+        "zipCode": {"bsonType": "string"}
+      }
+    }
+  }
+}`
+
+const orderSource = `// Code generated by schemagen. DO NOT EDIT.
+
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Order: An order placed by a customer.
+type Order struct {
+	ID primitive.ObjectID ` + "`" + `json:"_id" bson:"_id"` + "`" + `
+	// CustomerID: The customer who placed the order.
+	CustomerID      primitive.ObjectID    ` + "`" + `json:"customer_id" bson:"customer_id"` + "`" + `
+	Lines           []OrderLines          ` + "`" + `json:"lines" bson:"lines"` + "`" + `
+	Metadata        map[string]string     ` + "`" + `json:"metadata,omitempty" bson:"metadata,omitempty"` + "`" + `
+	Notes           *string               ` + "`" + `json:"notes,omitempty" bson:"notes,omitempty"` + "`" + `
+	PlacedAt        time.Time             ` + "`" + `json:"placed_at" bson:"placed_at"` + "`" + `
+	ShippingAddress *Address              ` + "`" + `json:"shipping_address,omitempty" bson:"shipping_address,omitempty"` + "`" + `
+	Total           *primitive.Decimal128 ` + "`" + `json:"total,omitempty" bson:"total,omitempty"` + "`" + `
+}
+
+type OrderLines struct {
+	GiftWrap *bool  ` + "`" + `json:"giftWrap,omitempty" bson:"giftWrap,omitempty"` + "`" + `
+	Quantity int32  ` + "`" + `json:"quantity" bson:"quantity"` + "`" + `
+	Sku      string ` + "`" + `json:"sku" bson:"sku"` + "`" + `
+}
+
+type Address struct {
+	ZipCode *string ` + "`" + `json:"zipCode,omitempty" bson:"zipCode,omitempty"` + "`" + `
+}
+`
+
+func TestGenerate(t *testing.T) {
+	var schema appservices.JSONSchema
+	if err := json.Unmarshal([]byte(orderSchema), &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := Generate(&buf, &schema, nil); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+
+	if got := buf.String(); got != orderSource {
+		t.Errorf("Generate returned:\n%s\nexpected:\n%s", got, orderSource)
+	}
+}
+
+func TestGenerate_options(t *testing.T) {
+	schema := &appservices.JSONSchema{
+		BSONType:   BSONTypeObject,
+		Properties: map[string]*appservices.JSONSchema{"count": {BSONType: BSONTypeLong}},
+	}
+
+	if err := Generate(&bytes.Buffer{}, schema, nil); !errors.Is(err, ErrNoTypeName) {
+		t.Errorf("expected ErrNoTypeName, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	err := Generate(&buf, schema, &Options{Package: "store", TypeName: "Counter", Generator: "go run ./cmd/schemagen"})
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+
+	expected := "// Code generated by go run ./cmd/schemagen. DO NOT EDIT.\n\npackage store\n\n" +
+		"type Counter struct {\n\tCount *int64 `json:\"count,omitempty\" bson:\"count,omitempty\"`\n}\n"
+	if got := buf.String(); got != expected {
+		t.Errorf("Generate returned:\n%s\nexpected:\n%s", got, expected)
+	}
+
+	schema.Properties["count"].BSONType = "regex"
+	if err := Generate(&bytes.Buffer{}, schema, &Options{TypeName: "Counter"}); err == nil {
+		t.Error("expected an error for an unsupported bsonType")
+	}
+}
+
+func TestGenerate_bsonTypeArrays(t *testing.T) {
+	var schema appservices.JSONSchema
+	input := `{
+	  "bsonType": ["object"],
+	  "required": ["name", "value"],
+	  "properties": {
+	    "name": {"bsonType": ["string", "null"], "maxLength": 20},
+	    "value": {"bsonType": ["string", "int"]},
+	    "tags": {"bsonType": "array", "items": {"bsonType": ["null", "string"]}}
+	  }
+	}`
+	if err := json.Unmarshal([]byte(input), &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := Generate(&buf, &schema, &Options{TypeName: "Label"}); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+
+	expected := "// Code generated by schemagen. DO NOT EDIT.\n\npackage models\n\n" +
+		"type Label struct {\n" +
+		"\tName  *string     `json:\"name\" bson:\"name\"`\n" +
+		"\tTags  []*string   `json:\"tags,omitempty\" bson:\"tags,omitempty\"`\n" +
+		"\tValue interface{} `json:\"value\" bson:\"value\"`\n" +
+		"}\n"
+	if got := buf.String(); got != expected {
+		t.Errorf("Generate returned:\n%s\nexpected:\n%s", got, expected)
+	}
+
+	schema.BSONTypes = []string{BSONTypeArray}
+	if err := Generate(&bytes.Buffer{}, &schema, &Options{TypeName: "Label"}); err == nil {
+		t.Error("expected an error for a root schema that is not an object")
+	}
+}
+
+func TestExportedName(t *testing.T) {
+	tests := map[string]string{
+		"_id":         "ID",
+		"customer_id": "CustomerID",
+		"customerId":  "CustomerID",
+		"apiURL":      "APIURL",
+		"URLPath":     "URLPath",
+		"zip-code":    "ZipCode",
+		"2fa_enabled": "X2faEnabled",
+		"__":          "",
+	}
+
+	for property, expected := range tests {
+		if got := exportedName(property); got != expected {
+			t.Errorf("exportedName(%q) = %q, expected %q", property, got, expected)
+		}
+	}
+}
@@ -0,0 +1,361 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userbulk
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appservices"
+)
+
+const (
+	defaultConcurrency    = 4
+	defaultEmailColumn    = "email"
+	defaultPasswordColumn = "password"
+)
+
+// ImportOptions configures Import.
+type ImportOptions struct {
+	// Concurrency is the maximum number of users created at once. Defaults to 4.
+	Concurrency int
+	// EmailColumn is the name of the CSV column holding the email addresses. Defaults to "email".
+	EmailColumn string
+	// PasswordColumn is the name of the CSV column holding the passwords. Defaults to "password".
+	PasswordColumn string
+	// Checkpoint records the progress of the import. When set, the rows it
+	// reports as processed are skipped, so an interrupted import can be run
+	// again with the same file to resume it.
+	Checkpoint Checkpoint
+}
+
+// Checkpoint stores the number of leading rows of an import that were processed.
+type Checkpoint interface {
+	// Load returns the number of leading rows already processed, 0 for a new import.
+	Load() (int, error)
+	// Save records that the first rows rows were processed.
+	Save(rows int) error
+}
+
+// FileCheckpoint is a Checkpoint stored in the file at the given path.
+type FileCheckpoint string
+
+// Load reads the checkpoint file, and returns 0 if it does not exist.
+func (c FileCheckpoint) Load() (int, error) {
+	b, err := os.ReadFile(string(c))
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	rows, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return 0, fmt.Errorf("userbulk: invalid checkpoint file %s: %w", c, err)
+	}
+	return rows, nil
+}
+
+// Save replaces the checkpoint file atomically.
+func (c FileCheckpoint) Save(rows int) error {
+	path := string(c)
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(tmp, "%d\n", rows); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ImportResult summarizes an import.
+type ImportResult struct {
+	// Created is the number of users created.
+	Created int
+	// Existing is the number of rows whose email address was already registered.
+	Existing int
+	// Resumed is the number of rows skipped because the checkpoint reported them as processed.
+	Resumed int
+	// Errors lists the rows that could not be created, ordered by row.
+	Errors []RowError
+}
+
+// RowError describes a CSV row that could not be imported.
+type RowError struct {
+	// Row is the 1-based number of the row, not counting the header.
+	Row   int
+	Email string
+	Err   error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d (%s): %v", e.Row, e.Email, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+type importRow struct {
+	number   int
+	email    string
+	password appservices.SensitiveString
+	err      error
+}
+
+type importer struct {
+	ctx        context.Context
+	cancel     context.CancelFunc
+	users      appservices.UsersService
+	groupID    string
+	appID      string
+	checkpoint Checkpoint
+	// progress hands the latest number of processed rows to saveProgress.
+	progress chan int
+
+	mu     sync.Mutex
+	result ImportResult
+	done   map[int]bool
+	next   int
+
+	// saveErr is only set by saveProgress.
+	saveErr error
+}
+
+// Import creates an email/password user for each row of the CSV read from r,
+// whose header row must name the email and password columns.
+//
+// Rows are processed concurrently. A row that cannot be created is reported in
+// ImportResult.Errors and counts as processed, so resuming an import does not
+// retry it. A row whose email address is already registered counts towards
+// ImportResult.Existing. The checkpoint is saved in the background as rows
+// complete. The returned error is only set when the import stopped early,
+// because the CSV could not be read, the checkpoint could not be loaded or
+// saved, or ctx was canceled.
+func Import(parent context.Context, users appservices.UsersService, groupID, appID string, r io.Reader, opts *ImportOptions) (*ImportResult, error) {
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+	emailColumn := opts.EmailColumn
+	if emailColumn == "" {
+		emailColumn = defaultEmailColumn
+	}
+	passwordColumn := opts.PasswordColumn
+	if passwordColumn == "" {
+		passwordColumn = defaultPasswordColumn
+	}
+
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("userbulk: reading CSV header: %w", err)
+	}
+	emailIndex, passwordIndex := indexOf(header, emailColumn), indexOf(header, passwordColumn)
+	if emailIndex < 0 || passwordIndex < 0 {
+		return nil, fmt.Errorf("userbulk: CSV header must contain the %q and %q columns", emailColumn, passwordColumn)
+	}
+
+	start := 0
+	if opts.Checkpoint != nil {
+		if start, err = opts.Checkpoint.Load(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Canceled as well when the checkpoint cannot be saved.
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	imp := &importer{
+		ctx:        ctx,
+		cancel:     cancel,
+		users:      users,
+		groupID:    groupID,
+		appID:      appID,
+		checkpoint: opts.Checkpoint,
+		progress:   make(chan int, 1),
+		done:       map[int]bool{},
+		next:       start + 1,
+	}
+	imp.result.Resumed = start
+
+	saved := make(chan struct{})
+	go imp.saveProgress(saved)
+
+	rows := make(chan importRow)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for row := range rows {
+				imp.create(row)
+			}
+		}()
+	}
+
+	readErr := readRows(ctx, cr, start, emailIndex, passwordIndex, rows)
+	close(rows)
+	wg.Wait()
+	close(imp.progress)
+	<-saved
+
+	sort.Slice(imp.result.Errors, func(i, j int) bool { return imp.result.Errors[i].Row < imp.result.Errors[j].Row })
+	switch {
+	case readErr != nil:
+		return &imp.result, readErr
+	case imp.saveErr != nil:
+		return &imp.result, imp.saveErr
+	}
+	return &imp.result, parent.Err()
+}
+
+// readRows sends the rows after the first start ones to rows, until the CSV is
+// exhausted or ctx is canceled.
+func readRows(ctx context.Context, cr *csv.Reader, start, emailIndex, passwordIndex int, rows chan<- importRow) error {
+	for number := 1; ; number++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return fmt.Errorf("userbulk: reading CSV row %d: %w", number, err)
+		}
+		if number <= start {
+			continue
+		}
+
+		row := importRow{number: number, err: err}
+		if err == nil {
+			row.email = strings.TrimSpace(record[emailIndex])
+			row.password = appservices.SensitiveString(record[passwordIndex])
+		}
+
+		select {
+		case rows <- row:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (imp *importer) create(row importRow) {
+	err := row.err
+	if err == nil && row.email == "" {
+		err = errors.New("missing email")
+	}
+	if err == nil {
+		_, _, err = imp.users.Create(imp.ctx, imp.groupID, imp.appID, &appservices.UserRequest{Email: row.email, Password: row.password})
+		if err != nil && imp.ctx.Err() != nil {
+			// Interrupted, leave the row to be retried when the import is resumed.
+			return
+		}
+	}
+
+	imp.mu.Lock()
+	defer imp.mu.Unlock()
+
+	switch {
+	case err == nil:
+		imp.result.Created++
+	case isConflict(err):
+		imp.result.Existing++
+	default:
+		imp.result.Errors = append(imp.result.Errors, RowError{Row: row.number, Email: row.email, Err: err})
+	}
+	imp.markDone(row.number)
+}
+
+// markDone records that a row was processed, and hands the number of rows
+// processed without a gap to saveProgress when it advances. It must be called
+// with imp.mu held.
+func (imp *importer) markDone(number int) {
+	imp.done[number] = true
+	advanced := false
+	for imp.done[imp.next] {
+		delete(imp.done, imp.next)
+		imp.next++
+		advanced = true
+	}
+	if !advanced || imp.checkpoint == nil {
+		return
+	}
+
+	// Replace a progress saveProgress has not picked up yet, so that it only
+	// saves the latest one. Sends happen with imp.mu held, so once drained the
+	// channel has room.
+	select {
+	case imp.progress <- imp.next - 1:
+	default:
+		select {
+		case <-imp.progress:
+		default:
+		}
+		imp.progress <- imp.next - 1
+	}
+}
+
+// saveProgress saves the progress received from markDone until it is closed,
+// and cancels the import when the checkpoint cannot be saved. It closes saved
+// when it returns.
+func (imp *importer) saveProgress(saved chan<- struct{}) {
+	defer close(saved)
+	for rows := range imp.progress {
+		if imp.saveErr != nil {
+			continue
+		}
+		if err := imp.checkpoint.Save(rows); err != nil {
+			imp.saveErr = fmt.Errorf("userbulk: saving checkpoint: %w", err)
+			imp.cancel()
+		}
+	}
+}
+
+func isConflict(err error) bool {
+	var errorResponse *appservices.ErrorResponse
+	return errors.As(err, &errorResponse) && errorResponse.Response != nil &&
+		errorResponse.Response.StatusCode == http.StatusConflict
+}
+
+func indexOf(header []string, column string) int {
+	for i, name := range header {
+		if strings.TrimSpace(name) == column {
+			return i
+		}
+	}
+	return -1
+}
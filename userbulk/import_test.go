@@ -0,0 +1,218 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userbulk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appservices"
+)
+
+// createHandler records the created users, and answers 409 for taken@example.com
+// and 400 for bad@example.com.
+type createHandler struct {
+	t *testing.T
+
+	mu      sync.Mutex
+	created []string
+}
+
+func (h *createHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/groups/"+groupID+"/apps/"+appID+"/users" {
+		h.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var req map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.t.Errorf("Decode json: %v", err)
+	}
+
+	switch req["email"] {
+	case "taken@example.com":
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"error_code": "AccountNameInUse", "error": "name already in use"}`))
+	case "bad@example.com":
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error_code": "InvalidPassword", "error": "password must be between 6 and 128 characters"}`))
+	default:
+		if req["password"] == "" {
+			h.t.Errorf("missing password for %s", req["email"])
+		}
+		h.mu.Lock()
+		h.created = append(h.created, req["email"])
+		h.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"_id": "1", "type": "normal"}`))
+	}
+}
+
+func (h *createHandler) createdEmails() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	emails := append([]string(nil), h.created...)
+	sort.Strings(emails)
+	return emails
+}
+
+const importCSV = `name,email,password
+Jane,jane@example.com,s3cr3tpass
+Taken,taken@example.com,s3cr3tpass
+Bad,bad@example.com,x
+Nobody,,s3cr3tpass
+Short,short@example.com
+John,john@example.com,s3cr3tpass
+`
+
+func TestImport(t *testing.T) {
+	handler := &createHandler{t: t}
+	client := newClient(t, handler)
+
+	checkpoint := FileCheckpoint(filepath.Join(t.TempDir(), "checkpoint"))
+
+	result, err := Import(context.Background(), client.Users, groupID, appID, strings.NewReader(importCSV), &ImportOptions{
+		Concurrency: 3,
+		Checkpoint:  checkpoint,
+	})
+	if err != nil {
+		t.Fatalf("Import returned error: %v", err)
+	}
+
+	if diff := deep.Equal(handler.createdEmails(), []string{"jane@example.com", "john@example.com"}); diff != nil {
+		t.Error(diff)
+	}
+	if result.Created != 2 || result.Existing != 1 || result.Resumed != 0 {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	rows := make([]int, len(result.Errors))
+	for i := range result.Errors {
+		rows[i] = result.Errors[i].Row
+		if strings.Contains(result.Errors[i].Error(), "s3cr3tpass") {
+			t.Errorf("row error exposed the password: %v", &result.Errors[i])
+		}
+	}
+	if diff := deep.Equal(rows, []int{3, 4, 5}); diff != nil {
+		t.Error(diff)
+	}
+	var errorResponse *appservices.ErrorResponse
+	if !errors.As(&result.Errors[0], &errorResponse) || errorResponse.ErrorCode != "InvalidPassword" {
+		t.Errorf("expected an InvalidPassword error, got %v", &result.Errors[0])
+	}
+
+	if rows, err := checkpoint.Load(); err != nil || rows != 6 {
+		t.Errorf("checkpoint.Load() = %d, %v, expected 6", rows, err)
+	}
+}
+
+func TestImport_resume(t *testing.T) {
+	handler := &createHandler{t: t}
+	client := newClient(t, handler)
+
+	checkpoint := FileCheckpoint(filepath.Join(t.TempDir(), "checkpoint"))
+	if err := checkpoint.Save(5); err != nil {
+		t.Fatalf("checkpoint.Save returned error: %v", err)
+	}
+
+	result, err := Import(context.Background(), client.Users, groupID, appID, strings.NewReader(importCSV), &ImportOptions{Checkpoint: checkpoint})
+	if err != nil {
+		t.Fatalf("Import returned error: %v", err)
+	}
+
+	if diff := deep.Equal(handler.createdEmails(), []string{"john@example.com"}); diff != nil {
+		t.Error(diff)
+	}
+	expected := &ImportResult{Created: 1, Resumed: 5}
+	if diff := deep.Equal(result, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestImport_customColumns(t *testing.T) {
+	handler := &createHandler{t: t}
+	client := newClient(t, handler)
+
+	input := "login,secret\njane@example.com,s3cr3tpass\n"
+	result, err := Import(context.Background(), client.Users, groupID, appID, strings.NewReader(input), &ImportOptions{
+		EmailColumn:    "login",
+		PasswordColumn: "secret",
+	})
+	if err != nil {
+		t.Fatalf("Import returned error: %v", err)
+	}
+	if result.Created != 1 {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	if _, err := Import(context.Background(), client.Users, groupID, appID, strings.NewReader(input), nil); err == nil {
+		t.Error("expected an error for missing columns")
+	}
+}
+
+// failingCheckpoint cannot save the progress of an import.
+type failingCheckpoint struct{}
+
+var errSave = errors.New("disk full")
+
+func (failingCheckpoint) Load() (int, error) { return 0, nil }
+func (failingCheckpoint) Save(int) error     { return errSave }
+
+func TestImport_checkpointFailure(t *testing.T) {
+	handler := &createHandler{t: t}
+	client := newClient(t, handler)
+
+	result, err := Import(context.Background(), client.Users, groupID, appID, strings.NewReader(importCSV), &ImportOptions{
+		Concurrency: 1,
+		Checkpoint:  failingCheckpoint{},
+	})
+	if !errors.Is(err, errSave) {
+		t.Fatalf("Import returned %v, expected %v", err, errSave)
+	}
+	if result == nil {
+		t.Fatal("expected the partial result of the import")
+	}
+}
+
+func TestFileCheckpoint(t *testing.T) {
+	checkpoint := FileCheckpoint(filepath.Join(t.TempDir(), "checkpoint"))
+
+	if rows, err := checkpoint.Load(); err != nil || rows != 0 {
+		t.Errorf("checkpoint.Load() = %d, %v, expected 0 for a missing file", rows, err)
+	}
+	if err := checkpoint.Save(42); err != nil {
+		t.Fatalf("checkpoint.Save returned error: %v", err)
+	}
+	if rows, err := checkpoint.Load(); err != nil || rows != 42 {
+		t.Errorf("checkpoint.Load() = %d, %v, expected 42", rows, err)
+	}
+
+	if err := os.WriteFile(string(checkpoint), []byte("nope"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := checkpoint.Load(); err == nil {
+		t.Error("expected an error for an invalid checkpoint")
+	}
+}
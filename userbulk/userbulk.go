@@ -0,0 +1,217 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package userbulk exports the users of an app and bulk-creates email/password
+// users, to move users between apps.
+//
+// Export walks every page of UsersService.List and writes the users as
+// newline-delimited JSON or CSV. Import creates the users listed in a CSV file
+// concurrently, reports the rows it could not create, and records its progress
+// in a Checkpoint so an interrupted import can be resumed.
+package userbulk // import "github.com/mongodb-labs/go-client-mongodb-atlas-app-services/userbulk"
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appservices"
+)
+
+// Format is the encoding of exported users.
+type Format string
+
+const (
+	// FormatNDJSON writes one JSON encoded user per line, with every field of the user.
+	FormatNDJSON Format = "ndjson"
+	// FormatCSV writes a header row followed by one row per user, with the columns of ExportOptions.Columns.
+	FormatCSV Format = "csv"
+)
+
+// Columns that can be exported to CSV. Fields of the user data and custom data
+// are exported with the "data." and "custom_data." prefixes, as in "data.name".
+const (
+	ColumnID                     = "id"
+	ColumnEmail                  = "email"
+	ColumnType                   = "type"
+	ColumnDisabled               = "disabled"
+	ColumnCreationDate           = "creation_date"
+	ColumnLastAuthenticationDate = "last_authentication_date"
+	// ColumnProviderTypes lists the provider types of the user identities, separated by semicolons.
+	ColumnProviderTypes = "provider_types"
+
+	dataPrefix       = "data."
+	customDataPrefix = "custom_data."
+)
+
+// DefaultColumns are the CSV columns exported when ExportOptions.Columns is empty.
+var DefaultColumns = []string{
+	ColumnID,
+	ColumnEmail,
+	ColumnType,
+	ColumnDisabled,
+	ColumnCreationDate,
+	ColumnLastAuthenticationDate,
+	ColumnProviderTypes,
+}
+
+// ExportOptions configures Export.
+type ExportOptions struct {
+	// Format defaults to FormatNDJSON.
+	Format Format
+	// Columns are the CSV columns to export, in order. Defaults to DefaultColumns.
+	Columns []string
+}
+
+// Export writes every user of an app to w and returns the number of users written.
+func Export(ctx context.Context, users appservices.UsersService, groupID, appID string, w io.Writer, opts *ExportOptions) (int, error) {
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+
+	var write func(*appservices.User) error
+	var flush func() error
+	switch opts.Format {
+	case FormatNDJSON, "":
+		enc := json.NewEncoder(w)
+		write = func(u *appservices.User) error { return enc.Encode(u) }
+		flush = func() error { return nil }
+	case FormatCSV:
+		columns := opts.Columns
+		if len(columns) == 0 {
+			columns = DefaultColumns
+		}
+		for _, column := range columns {
+			if !validColumn(column) {
+				return 0, fmt.Errorf("userbulk: unknown column %q", column)
+			}
+		}
+		cw := csv.NewWriter(w)
+		if err := cw.Write(columns); err != nil {
+			return 0, err
+		}
+		write = func(u *appservices.User) error { return cw.Write(csvRecord(u, columns)) }
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	default:
+		return 0, fmt.Errorf("userbulk: unknown format %q", opts.Format)
+	}
+
+	n := 0
+	listOptions := &appservices.UserListOptions{}
+	for {
+		page, _, err := users.List(ctx, groupID, appID, listOptions)
+		if err != nil {
+			return n, err
+		}
+		if len(page) == 0 {
+			break
+		}
+		for i := range page {
+			if err := write(&page[i]); err != nil {
+				return n, err
+			}
+			n++
+		}
+		listOptions.After = page[len(page)-1].ID
+	}
+
+	return n, flush()
+}
+
+func validColumn(column string) bool {
+	switch column {
+	case ColumnID, ColumnEmail, ColumnType, ColumnDisabled, ColumnCreationDate,
+		ColumnLastAuthenticationDate, ColumnProviderTypes:
+		return true
+	}
+	return len(column) > len(dataPrefix) && strings.HasPrefix(column, dataPrefix) ||
+		len(column) > len(customDataPrefix) && strings.HasPrefix(column, customDataPrefix)
+}
+
+func csvRecord(u *appservices.User, columns []string) []string {
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = columnValue(u, column)
+	}
+	return record
+}
+
+func columnValue(u *appservices.User, column string) string {
+	switch column {
+	case ColumnID:
+		return u.ID
+	case ColumnEmail:
+		return email(u)
+	case ColumnType:
+		return u.Type
+	case ColumnDisabled:
+		return strconv.FormatBool(u.Disabled)
+	case ColumnCreationDate:
+		return formatDate(u.CreationDate)
+	case ColumnLastAuthenticationDate:
+		return formatDate(u.LastAuthenticationDate)
+	case ColumnProviderTypes:
+		types := make([]string, len(u.Identities))
+		for i := range u.Identities {
+			types[i] = u.Identities[i].ProviderType
+		}
+		return strings.Join(types, ";")
+	}
+	if field, ok := strings.CutPrefix(column, customDataPrefix); ok {
+		return formatValue(u.CustomData[field])
+	}
+	return formatValue(u.Data[strings.TrimPrefix(column, dataPrefix)])
+}
+
+// email returns the email address of the user data or, failing that, of its identities.
+func email(u *appservices.User) string {
+	if e, ok := u.Data["email"].(string); ok {
+		return e
+	}
+	for i := range u.Identities {
+		if e, ok := u.Identities[i].ProviderData["email"].(string); ok {
+			return e
+		}
+	}
+	return ""
+}
+
+func formatDate(date *int64) string {
+	if date == nil {
+		return ""
+	}
+	return strconv.FormatInt(*date, 10)
+}
+
+// formatValue writes strings as is and any other value as JSON.
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,144 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userbulk
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/mongodb-labs/go-client-mongodb-atlas-app-services/appservices"
+)
+
+const (
+	groupID = "6c7498dg87d9e6526801572b"
+	appID   = "5c7498dg87d9e6526801572b"
+)
+
+// newClient returns a client of a test server serving handler.
+func newClient(t *testing.T, handler http.Handler) *appservices.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := appservices.New(nil, appservices.SetBaseURL(server.URL+"/"))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	return client
+}
+
+// usersHandler serves two pages of users, then an empty page.
+func usersHandler(t *testing.T) http.Handler {
+	t.Helper()
+
+	pages := map[string]string{
+		"": `[
+		  {
+		    "_id": "1",
+		    "type": "normal",
+		    "creation_date": 1600000000,
+		    "last_authentication_date": 1600000100,
+		    "data": {"email": "jane@example.com", "name": "Jane"},
+		    "custom_data": {"plan": "pro", "seats": 3},
+		    "identities": [{"id": "i1", "provider_type": "local-userpass"}, {"id": "i2", "provider_type": "api-key"}]
+		  },
+		  {"_id": "2", "type": "normal", "disabled": true, "identities": [{"id": "i3", "provider_type": "anon-user"}]}
+		]`,
+		"2": `[{"_id": "3", "type": "server", "identities": [{"id": "i4", "provider_type": "local-userpass", "provider_data": {"email": "john@example.com"}}]}]`,
+		"3": `[]`,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/groups/"+groupID+"/apps/"+appID+"/users", func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.Query().Get("after")]
+		if !ok {
+			t.Errorf("unexpected after %q", r.URL.Query().Get("after"))
+			page = `[]`
+		}
+		_, _ = w.Write([]byte(page))
+	})
+	return mux
+}
+
+func TestExport_NDJSON(t *testing.T) {
+	client := newClient(t, usersHandler(t))
+
+	var buf bytes.Buffer
+	n, err := Export(context.Background(), client.Users, groupID, appID, &buf, nil)
+	if err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("Export returned %d, expected 3", n)
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d: %s", len(lines), buf.Bytes())
+	}
+	expected := `{"_id":"3","type":"server","identities":[{"provider_data":{"email":"john@example.com"},"id":"i4","provider_type":"local-userpass"}]}`
+	if got := string(lines[2]); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestExport_CSV(t *testing.T) {
+	client := newClient(t, usersHandler(t))
+
+	opts := &ExportOptions{
+		Format:  FormatCSV,
+		Columns: []string{ColumnID, ColumnEmail, ColumnDisabled, ColumnCreationDate, ColumnProviderTypes, "data.name", "custom_data.seats"},
+	}
+
+	var buf bytes.Buffer
+	n, err := Export(context.Background(), client.Users, groupID, appID, &buf, opts)
+	if err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("Export returned %d, expected 3", n)
+	}
+
+	expected := "id,email,disabled,creation_date,provider_types,data.name,custom_data.seats\n" +
+		"1,jane@example.com,false,1600000000,local-userpass;api-key,Jane,3\n" +
+		"2,,true,,anon-user,,\n" +
+		"3,john@example.com,false,,local-userpass,,\n"
+	if diff := deep.Equal(buf.String(), expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestExport_invalidOptions(t *testing.T) {
+	client := newClient(t, http.NotFoundHandler())
+
+	for _, opts := range []*ExportOptions{
+		{Format: "xml"},
+		{Format: FormatCSV, Columns: []string{ColumnID, "name"}},
+		{Format: FormatCSV, Columns: []string{"data."}},
+	} {
+		var buf bytes.Buffer
+		if _, err := Export(context.Background(), client.Users, groupID, appID, &buf, opts); err == nil {
+			t.Errorf("expected an error for %+v", opts)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("expected nothing written for %+v, got %s", opts, buf.Bytes())
+		}
+	}
+}